@@ -1,9 +1,11 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/justwhenjing/gvm/internal/cmd"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
 )
 
 func main() {
@@ -13,6 +15,11 @@ func main() {
 	}
 
 	if err := rootCmd.Execute(); err != nil {
+		// 子进程退出状态码原样返回
+		var exitErr *core.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
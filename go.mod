@@ -3,12 +3,12 @@ module github.com/justwhenjing/gvm
 go 1.24.6
 
 require (
-	github.com/Masterminds/semver v1.5.0
 	github.com/c4milo/unpackit v1.0.0
 	github.com/go-playground/validator/v10 v10.28.0
 	github.com/go-resty/resty/v2 v2.16.5
 	github.com/schollz/progressbar/v3 v3.18.0
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/sys v0.36.0
 )
 
 require (
@@ -28,7 +28,6 @@ require (
 	github.com/ulikunitz/xz v0.5.10 // indirect
 	golang.org/x/crypto v0.42.0 // indirect
 	golang.org/x/net v0.43.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/term v0.35.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 )
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewCacheCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "cache",
+		Long: "manage cached go archives",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:     "ls",
+			Aliases: []string{"list"},
+			Long:    "list cached go archives",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r := runtime.NewRuntime(logger, c)
+				return r.ListArchives()
+			},
+		},
+		&cobra.Command{
+			Use:  "prune",
+			Long: "remove cached go archives exceeding --archive-max-size or --archive-max-age",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r := runtime.NewRuntime(logger, c)
+				return r.PruneArchives()
+			},
+		},
+		&cobra.Command{
+			Use:  "clear",
+			Long: "remove all cached go archives, partial downloads and the version list cache",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				r := runtime.NewRuntime(logger, c)
+				return r.ClearArchives()
+			},
+		},
+	)
+
+	return cmd
+}
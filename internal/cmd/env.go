@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewEnvCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "env [version]",
+		Long: "print environment exports for spec go version, current version if not specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var version string
+			if len(args) > 0 {
+				version = args[0]
+			}
+
+			if err := quiet(logger); err != nil {
+				return err
+			}
+
+			r := runtime.NewRuntime(logger, c, runtime.WithOutput(cmd.OutOrStdout()))
+			if err := r.Env(version); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&c.Shell, "shell", "", "",
+		"output format bash|zsh|fish|posix|json (default detected from $SHELL)")
+	cmd.PersistentFlags().StringVarP(&c.GoPath, "gopath", "", "", "also export GOPATH with this value")
+	cmd.PersistentFlags().StringVarP(&c.Toolchain, "toolchain", "", "", "also export GOTOOLCHAIN with this value, e.g. local")
+
+	return cmd
+}
+
+func NewInitCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "init [bash|zsh|fish|posix]",
+		Long: "print shell integration script to eval, switching version on cd by project version files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var shell string
+			if len(args) > 0 {
+				shell = args[0]
+			}
+
+			if err := quiet(logger); err != nil {
+				return err
+			}
+
+			r := runtime.NewRuntime(logger, c, runtime.WithOutput(cmd.OutOrStdout()))
+			if err := r.Init(shell); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(&c.Shims, "shims", "", false,
+		"if put shims on PATH to resolve the version per invocation instead of switching on cd")
+
+	return cmd
+}
+
+func NewShellCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "shell [version|--unset]",
+		Long: "print exports switching version for the current shell session only (evaluated by the gvm function from gvm init), show the effective version if not specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var version string
+			if len(args) > 0 {
+				version = args[0]
+			}
+
+			// 未指定版本时仅显示生效版本
+			if version != "" || c.Unset || c.Project {
+				if err := quiet(logger); err != nil {
+					return err
+				}
+			}
+
+			r := runtime.NewRuntime(logger, c, runtime.WithOutput(cmd.OutOrStdout()))
+			if err := r.Shell(version); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&c.Shell, "shell", "", "",
+		"output format bash|zsh|fish|posix|json (default detected from $SHELL)")
+	cmd.PersistentFlags().BoolVarP(&c.Unset, "unset", "", false, "if revert the session version")
+	cmd.PersistentFlags().BoolVarP(&c.Project, "project", "", false,
+		"if print exports for the project version of the current directory (global version if none), used by the cd hook")
+
+	return cmd
+}
+
+// quiet 输出供shell执行的内容时不显示日志(错误仍输出到标准错误)
+func quiet(logger log.ILog) error {
+	return logger.SetLevel(log.LevelError)
+}
@@ -25,7 +25,7 @@ func NewRootCmd() (*cobra.Command, error) {
 	}
 
 	// 初始化配置
-	c := &config.Config{}
+	c := &config.Config{GVMVersion: Version}
 
 	cmd := &cobra.Command{
 		Use:               "gvm",
@@ -62,12 +62,33 @@ func NewRootCmd() (*cobra.Command, error) {
 		NewInstallCmd(logger, c),
 		NewUninstallCmd(logger, c),
 		NewUseCmd(logger, c),
+		NewHistoryCmd(logger, c),
+		NewLinkCmd(logger, c),
+		NewInfoCmd(logger, c),
+		NewVerifyCmd(logger, c),
+		NewEnvCmd(logger, c),
+		NewInitCmd(logger, c),
+		NewShellCmd(logger, c),
+		NewRehashCmd(logger, c),
+		NewShimCmd(logger, c),
+		NewExecCmd(logger, c),
+		NewLocalCmd(logger, c),
+		NewCacheCmd(logger, c),
 		NewVersionCmd(),
 	)
 
 	// 设置选项
 	cmd.PersistentFlags().StringVarP(&c.RootDir, "root", "", os.Getenv("GVM_ROOT"), "gvm root directory")
 	cmd.PersistentFlags().StringVarP(&c.Repo, "repo", "", config.DefaultRepo, "gvm version repository")
+	cmd.PersistentFlags().StringSliceVarP(&c.Sources, "sources", "", nil,
+		"version sources in fallback order, kind[=location] with kind golang|tag|http|local (default golang)")
+	cmd.PersistentFlags().DurationVarP(&c.LockTimeout, "lock-timeout", "", config.DefaultLockWait,
+		"how long to wait for another gvm process holding the root lock")
+	cmd.PersistentFlags().IntVarP(&c.Retries, "retries", "", config.DefaultRetries, "how many times to retry a failed download")
+	cmd.PersistentFlags().Int64VarP(&c.ArchiveMaxSize, "archive-max-size", "", config.DefaultArchiveMaxSize,
+		"max total size in MiB of cached archives, 0 means unlimited")
+	cmd.PersistentFlags().DurationVarP(&c.ArchiveMaxAge, "archive-max-age", "", config.DefaultArchiveMaxAge,
+		"max unused age of cached archives, 0 means unlimited")
 	cmd.PersistentFlags().BoolVarP(&c.Verbose, "verbose", "v", false, "if show details")
 
 	return cmd, nil
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewInfoCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "info <version>",
+		Long: "show install manifest, disk usage and current state of an installed version",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := runtime.NewRuntime(logger, c)
+			if err := r.Info(args[0]); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
 
 	"github.com/justwhenjing/gvm/internal/controller/config"
@@ -10,12 +13,20 @@ import (
 
 func NewInstallCmd(logger log.ILog, c *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "install [version]",
+		Use:  "install [version] [--patch file...]",
 		Long: "install spec go version",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// 应用补丁时,shell展开的多余补丁文件作为补丁追加
 			var version string
-			if len(args) > 0 {
-				version = args[0]
+			for _, arg := range args {
+				if len(c.Patches) > 0 && isFile(arg) {
+					c.Patches = append(c.Patches, arg)
+					continue
+				}
+				if version != "" {
+					return fmt.Errorf("unexpected argument %q", arg)
+				}
+				version = arg
 			}
 
 			r := runtime.NewRuntime(logger, c)
@@ -27,5 +38,34 @@ func NewInstallCmd(logger log.ILog, c *config.Config) *cobra.Command {
 		},
 	}
 
+	cmd.PersistentFlags().IntVarP(&c.Concurrency, "concurrency", "", config.DefaultParallel,
+		"how many byte ranges to download concurrently when the server supports it")
+	cmd.PersistentFlags().BoolVarP(&c.Stream, "stream", "", false,
+		"if extract the archive while downloading (tar.gz only)")
+	cmd.PersistentFlags().BoolVarP(&c.InsecureSkipChecksum, "insecure-skip-checksum", "", false,
+		"if skip archive checksum verification (insecure)")
+	cmd.PersistentFlags().StringVarP(&c.FromFile, "from-file", "", "",
+		"install from a local archive file instead of downloading")
+	cmd.PersistentFlags().StringVarP(&c.FromDir, "from-dir", "", "",
+		"install from an already extracted GOROOT directory")
+	cmd.PersistentFlags().StringVarP(&c.Checksum, "checksum", "", "",
+		"expected sha256 of the archive given by --from-file")
+	cmd.PersistentFlags().StringVarP(&c.SourceRepo, "source", "", "",
+		"build from a go source git url or local path")
+	cmd.PersistentFlags().StringVarP(&c.Ref, "ref", "", "",
+		"branch, tag or commit to build with --source (default branch if empty)")
+	cmd.PersistentFlags().StringVarP(&c.Name, "name", "", "",
+		"version name to install the built toolchain as")
+	cmd.PersistentFlags().StringSliceVarP(&c.Patches, "patch", "", nil,
+		"patch files applied in order before building, glob patterns allowed")
+	cmd.PersistentFlags().StringVarP(&c.Bootstrap, "bootstrap", "", "",
+		"installed version used as GOROOT_BOOTSTRAP (default latest installed stable)")
+
 	return cmd
 }
+
+// isFile 判断参数是否为已存在的文件
+func isFile(arg string) bool {
+	info, err := os.Stat(arg)
+	return err == nil && info.Mode().IsRegular()
+}
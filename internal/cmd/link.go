@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewLinkCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "link <name> <goroot>",
+		Long: "register an existing go root as a named version, uninstall only unregisters it",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := runtime.NewRuntime(logger, c)
+			if err := r.Link(args[0], args[1]); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
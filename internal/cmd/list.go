@@ -39,7 +39,6 @@ func NewListCmd(logger log.ILog, c *config.Config) *cobra.Command {
 
 	cmd.PersistentFlags().BoolVarP(&c.Remote, "remote", "", false, "if show remote versions")
 	cmd.PersistentFlags().BoolVarP(&c.ClearCache, "clear-cache", "", false, "if clear cache")
-	cmd.PersistentFlags().StringVarP(&c.TagURL, "tag-url", "", config.DefaultTagURL, "tag url")
 	cmd.PersistentFlags().DurationVarP(&c.CacheTTL, "cache-ttl", "", config.DefaultCacheTTL, "cache ttl")
 
 	return cmd
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewLocalCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "local [version]",
+		Long: "write .go-version in current directory and use it, or show the project version if not specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var version string
+			if len(args) > 0 {
+				version = args[0]
+			}
+
+			r := runtime.NewRuntime(logger, c)
+			if err := r.Local(version); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
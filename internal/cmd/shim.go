@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewRehashCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "rehash",
+		Long: "regenerate shims in $GVM_ROOT/shims for tools of all installed versions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := runtime.NewRuntime(logger, c)
+			if err := r.Rehash(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func NewShimCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                "shim <tool> [args...]",
+		Long:               "run tool of the effective version, invoked by shims",
+		Hidden:             true,
+		DisableFlagParsing: true,
+		SilenceErrors:      true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("tool is required")
+			}
+
+			// 垫片的输出即工具的输出,不显示gvm日志
+			if err := logger.SetLevel(log.LevelError); err != nil {
+				return err
+			}
+
+			r := runtime.NewRuntime(logger, c)
+			return printError(cmd, r.Shim(args[0], args[1:]))
+		},
+	}
+
+	return cmd
+}
+
+func NewExecCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "exec <version> -- <command> [args...]",
+		Long:          "run a command with spec go version without switching the current version",
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 || cmd.ArgsLenAtDash() != 1 {
+				return printError(cmd, fmt.Errorf("usage: gvm exec <version> -- <command> [args...]"))
+			}
+
+			// 命令的输出不混入gvm日志
+			if !c.Verbose {
+				if err := logger.SetLevel(log.LevelWarn); err != nil {
+					return err
+				}
+			}
+
+			r := runtime.NewRuntime(logger, c)
+			return printError(cmd, r.Exec(args[0], args[1:]))
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(&c.InstallMissing, "install", "", false, "if install the version when it is not installed")
+
+	return cmd
+}
+
+// printError 显示错误(子进程的退出状态码不显示,由gvm原样返回)
+func printError(cmd *cobra.Command, err error) error {
+	var exitErr *core.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		cmd.PrintErrln("gvm:", err)
+	}
+	return err
+}
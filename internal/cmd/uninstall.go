@@ -12,16 +12,15 @@ import (
 
 func NewUninstallCmd(logger log.ILog, c *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "uninstall [version]",
-		Long: "uninstall spec go version",
+		Use:  "uninstall [version...]",
+		Long: "uninstall spec go versions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("version is required")
 			}
-			version := args[0]
 
 			r := runtime.NewRuntime(logger, c)
-			if err := r.Uninstall(version); err != nil {
+			if err := r.Uninstall(args...); err != nil {
 				return err
 			}
 
@@ -29,5 +28,8 @@ func NewUninstallCmd(logger log.ILog, c *config.Config) *cobra.Command {
 		},
 	}
 
+	cmd.PersistentFlags().BoolVarP(&c.Force, "force", "f", false, "if uninstall the version in use")
+	cmd.PersistentFlags().BoolVarP(&c.DryRun, "dry-run", "", false, "if only show what would be removed")
+
 	return cmd
 }
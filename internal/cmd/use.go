@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
 	"github.com/justwhenjing/gvm/internal/controller/config"
@@ -12,13 +10,13 @@ import (
 
 func NewUseCmd(logger log.ILog, c *config.Config) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "use [version]",
-		Long: "use spec go version",
+		Use:  "use [version|-]",
+		Long: "use spec go version, the previous version with -, or the version declared by .go-version/.gvmrc/go.mod if not specified",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return fmt.Errorf("version is required")
+			var version string
+			if len(args) > 0 {
+				version = args[0]
 			}
-			version := args[0]
 
 			r := runtime.NewRuntime(logger, c)
 			if err := r.Use(version); err != nil {
@@ -31,3 +29,22 @@ func NewUseCmd(logger log.ILog, c *config.Config) *cobra.Command {
 
 	return cmd
 }
+
+func NewHistoryCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "history",
+		Long: "show recent version switches",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := runtime.NewRuntime(logger, c)
+			if err := r.History(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().IntVarP(&c.Limit, "limit", "n", 20, "how many recent switches to show, 0 means all")
+
+	return cmd
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+func NewVerifyCmd(logger log.ILog, c *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "verify [version|--all]",
+		Long: "verify installed files against the hashes recorded at install time, current version if not specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var version string
+			if len(args) > 0 {
+				version = args[0]
+			}
+
+			r := runtime.NewRuntime(logger, c)
+			if err := r.Verify(version); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().BoolVarP(&c.All, "all", "", false, "if verify all installed versions")
+	cmd.PersistentFlags().BoolVarP(&c.Repair, "repair", "", false,
+		"if reinstall versions failing verification from the cached archive")
+
+	return cmd
+}
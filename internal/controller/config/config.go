@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -15,17 +16,73 @@ const (
 	DefaultRootDir  = "gvm"
 	DefaultRepo     = "https://go.dev/dl/"
 	DefaultCacheTTL = time.Duration(10) * time.Minute
-	DefaultTagURL   = "https://raw.githubusercontent.com/kevincobain2000/gobrew/json/golang-tags.json"
+	DefaultLockWait = time.Duration(10) * time.Minute
+	DefaultRetries  = 3
+	DefaultParallel = 4
+
+	DefaultArchiveMaxSize = 2048                             // 压缩包缓存最大总大小(MiB)
+	DefaultArchiveMaxAge  = time.Duration(30*24) * time.Hour // 压缩包缓存最长未使用时间
+	DefaultTagURL         = "https://raw.githubusercontent.com/kevincobain2000/gobrew/json/golang-tags.json"
+)
+
+// 版本源类型
+const (
+	SourceGolang = "golang" // 官方发布版本索引
+	SourceTag    = "tag"    // git标签列表
+	SourceHTTP   = "http"   // HTTP目录列表
+	SourceLocal  = "local"  // 本地目录
+)
+
+// shell类型
+const (
+	ShellBash  = "bash"
+	ShellZsh   = "zsh"
+	ShellFish  = "fish"
+	ShellPosix = "posix"
+	ShellJSON  = "json"
 )
 
 type Config struct {
-	RootDir    string        `json:"root_dir" validate:"required"`     // gvm根目录
-	Repo       string        `json:"repo" validate:"required"`         // 版本仓库
-	TagURL     string        `json:"tag_url" validate:"required"`      // 版本标签URL
-	Verbose    bool          `json:"verbose" validate:"omitempty"`     // 是否显示详细信息
-	Remote     bool          `json:"remote" validate:"omitempty"`      // 是否显示远程版本信息
-	ClearCache bool          `json:"clear_cache" validate:"omitempty"` // 是否清理缓存
-	CacheTTL   time.Duration `json:"cache_ttl" validate:"omitempty"`   // 缓存过期时间
+	RootDir     string        `json:"root_dir" validate:"required"`      // gvm根目录
+	Repo        string        `json:"repo" validate:"required"`          // 版本仓库
+	Sources     []string      `json:"sources" validate:"omitempty"`      // 版本源(kind[=location],按顺序回退)
+	Verbose     bool          `json:"verbose" validate:"omitempty"`      // 是否显示详细信息
+	GVMVersion  string        `json:"gvm_version" validate:"omitempty"`  // gvm版本
+	LockTimeout time.Duration `json:"lock_timeout" validate:"omitempty"` // 等待根目录锁超时时间
+	Retries     int           `json:"retries" validate:"gte=0"`          // 下载失败重试次数
+	Concurrency int           `json:"concurrency" validate:"gte=0"`      // 分块下载并发数(不大于1时单连接下载)
+	Remote      bool          `json:"remote" validate:"omitempty"`       // 是否显示远程版本信息
+	ClearCache  bool          `json:"clear_cache" validate:"omitempty"`  // 是否清理缓存
+	CacheTTL    time.Duration `json:"cache_ttl" validate:"omitempty"`    // 缓存过期时间
+	Force       bool          `json:"force" validate:"omitempty"`        // 是否强制执行
+	DryRun      bool          `json:"dry_run" validate:"omitempty"`      // 是否仅演示不执行
+	All         bool          `json:"all" validate:"omitempty"`          // 是否作用于所有版本
+	Repair      bool          `json:"repair" validate:"omitempty"`       // 是否修复校验失败的版本
+	Limit       int           `json:"limit" validate:"gte=0"`            // 显示的记录条数(0表示不限制)
+
+	InsecureSkipChecksum bool `json:"insecure_skip_checksum" validate:"omitempty"` // 是否跳过校验和检查(不安全)
+	Stream               bool `json:"stream" validate:"omitempty"`                 // 是否边下载边解压
+
+	FromFile string `json:"from_file" validate:"omitempty,excluded_with=FromDir"` // 从本地压缩包安装
+	FromDir  string `json:"from_dir" validate:"omitempty"`                        // 从已解压的GOROOT安装
+	Checksum string `json:"checksum" validate:"omitempty"`                        // 本地压缩包的sha256(为空时不校验)
+
+	SourceRepo string   `json:"source_repo" validate:"omitempty,excluded_with=FromFile FromDir"` // 源码仓库(git地址或本地路径)
+	Ref        string   `json:"ref" validate:"omitempty"`                                        // 源码检出的分支、标签或提交
+	Name       string   `json:"name" validate:"omitempty"`                                       // 源码构建安装的版本名称
+	Patches    []string `json:"patches" validate:"omitempty"`                                    // 构建前按顺序应用的补丁文件
+	Bootstrap  string   `json:"bootstrap" validate:"omitempty"`                                  // 构建使用的已安装版本(默认最新稳定版本)
+
+	Shell          string `json:"shell" validate:"omitempty,oneof=bash zsh fish posix json"` // 输出环境变量的shell格式
+	GoPath         string `json:"go_path" validate:"omitempty"`                              // 输出的GOPATH(为空时不输出)
+	Toolchain      string `json:"toolchain" validate:"omitempty"`                            // 输出的GOTOOLCHAIN(为空时不输出)
+	Shims          bool   `json:"shims" validate:"omitempty"`                                // 是否使用垫片模式(按调用时的版本执行)
+	Unset          bool   `json:"unset" validate:"omitempty"`                                // 是否取消会话版本
+	Project        bool   `json:"project" validate:"omitempty"`                              // 是否输出项目版本的环境变量(切换目录钩子使用)
+	InstallMissing bool   `json:"install_missing" validate:"omitempty"`                      // 执行命令时是否安装缺失的版本
+
+	ArchiveMaxSize int64         `json:"archive_max_size" validate:"gte=0"`    // 压缩包缓存最大总大小(MiB,0表示不限制)
+	ArchiveMaxAge  time.Duration `json:"archive_max_age" validate:"omitempty"` // 压缩包缓存最长未使用时间(0表示不限制)
 }
 
 func (c *Config) BackFill() error {
@@ -42,6 +99,12 @@ func (c *Config) BackFill() error {
 }
 
 func (c *Config) Validate() error {
+	for _, spec := range c.Sources {
+		if _, _, err := c.ParseSource(spec); err != nil {
+			return err
+		}
+	}
+
 	err := validator.New().Struct(c)
 	if err == nil {
 		return nil
@@ -63,6 +126,32 @@ func (c *Config) Validate() error {
 	return fmt.Errorf("%s", format)
 }
 
+// ParseSource 解析版本源(kind[=location]),golang/tag 可省略location
+func (c *Config) ParseSource(spec string) (string, string, error) {
+	kind, location, _ := strings.Cut(spec, "=")
+	kind = strings.TrimSpace(kind)
+	location = strings.TrimSpace(location)
+
+	switch kind {
+	case SourceGolang:
+		if location == "" {
+			location = c.Repo
+		}
+	case SourceTag:
+		if location == "" {
+			location = DefaultTagURL
+		}
+	case SourceHTTP, SourceLocal:
+		if location == "" {
+			return "", "", fmt.Errorf("source %q requires a location", spec)
+		}
+	default:
+		return "", "", fmt.Errorf("unknown source kind %q, expect one of %s/%s/%s/%s",
+			kind, SourceGolang, SourceTag, SourceHTTP, SourceLocal)
+	}
+	return kind, location, nil
+}
+
 func (c *Config) String() string {
 	str, _ := json.Marshal(c)
 	return string(str)
@@ -0,0 +1,125 @@
+package project
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 项目版本文件(同一目录下按顺序优先)
+const (
+	GoVersionFile = ".go-version"
+	GvmrcFile     = ".gvmrc"
+	GoModFile     = "go.mod"
+)
+
+// ErrNotFound 未找到项目版本文件
+var ErrNotFound = errors.New("no .go-version, .gvmrc or go.mod found")
+
+// Spec 项目声明的版本
+type Spec struct {
+	Version string // 声明的版本(不带go前缀,go.mod的go指令可能只包含语言版本)
+	File    string // 来源文件
+	Minimum bool   // 是否为最低版本要求(go.mod的go指令)
+}
+
+// Find 从dir开始向上查找项目版本文件
+func Find(dir string) (*Spec, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		for _, name := range []string{GoVersionFile, GvmrcFile, GoModFile} {
+			fp := filepath.Join(dir, name)
+			if _, err := os.Stat(fp); err != nil {
+				continue
+			}
+
+			spec, err := parse(fp)
+			if err != nil {
+				return nil, err
+			}
+			if spec.Version == "" {
+				continue
+			}
+			return spec, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Write 在dir下写入 .go-version 文件
+func Write(dir string, version string) (string, error) {
+	fp := filepath.Join(dir, GoVersionFile)
+	if err := os.WriteFile(fp, []byte(version+"\n"), 0644); err != nil {
+		return "", err
+	}
+	return fp, nil
+}
+
+// parse 解析版本文件
+func parse(fp string) (*Spec, error) {
+	// #nosec G304
+	fObj, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fObj.Close()
+	}()
+
+	isGoMod := filepath.Base(fp) == GoModFile
+	var goVersion, toolchain string
+
+	scanner := bufio.NewScanner(fObj)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		// .go-version/.gvmrc 取第一个有效行
+		if !isGoMod {
+			return &Spec{Version: normalize(strings.Fields(line)[0]), File: fp}, nil
+		}
+
+		// go.mod 优先使用toolchain指令,其次go指令
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			goVersion = fields[1]
+		case "toolchain":
+			if fields[1] != "default" {
+				toolchain = fields[1]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s failed: %w", fp, err)
+	}
+
+	if toolchain != "" {
+		return &Spec{Version: normalize(toolchain), File: fp}, nil
+	}
+	return &Spec{Version: normalize(goVersion), File: fp, Minimum: isGoMod}, nil
+}
+
+// normalize 去除go前缀及toolchain后缀(如 go1.22.4+auto)
+func normalize(version string) string {
+	version = strings.TrimPrefix(version, "go")
+	version, _, _ = strings.Cut(version, "+")
+	return version
+}
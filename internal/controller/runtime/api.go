@@ -3,6 +3,26 @@ package runtime
 type IRuntime interface {
 	List(filter string) error
 	Use(version string) error
+	History() error
+	Local(version string) error
 	Install(version string) error
-	Uninstall(version string) error
+	Uninstall(versions ...string) error
+	Link(name string, goroot string) error
+	Info(version string) error
+	Verify(version string) error
+
+	// shell集成
+	Env(version string) error
+	Init(shell string) error
+	Shell(version string) error
+
+	// 垫片
+	Rehash() error
+	Shim(tool string, args []string) error
+	Exec(version string, command []string) error
+
+	// 压缩包缓存
+	ListArchives() error
+	PruneArchives() error
+	ClearArchives() error
 }
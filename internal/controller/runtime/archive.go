@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"os"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/store"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// ListArchives 列举缓存的压缩包
+func (r *Runtime) ListArchives() error {
+	entries, err := r.store.List()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+		r.logger.Info(entry.Version,
+			"sha256", entry.SHA256,
+			"size", fileop.HumanSize(entry.Size),
+			"last_used", entry.LastUsed.Format("2006-01-02 15:04:05"),
+		)
+	}
+	r.logger.Info("cached archives", "count", len(entries), "size", fileop.HumanSize(total))
+	return nil
+}
+
+// PruneArchives 按缓存限制清理压缩包
+func (r *Runtime) PruneArchives() error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	pruned, err := r.store.Prune()
+	if err != nil {
+		return err
+	}
+	r.logRemovedArchives(pruned)
+	return nil
+}
+
+// ClearArchives 清理全部缓存(压缩包、未完成的下载及版本列表缓存)
+func (r *Runtime) ClearArchives() error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cleared, err := r.store.Clear()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(r.o.downloadsDir); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(r.o.cacheFile); err != nil {
+		return err
+	}
+	r.logRemovedArchives(cleared)
+	return nil
+}
+
+// logRemovedArchives 显示被清理的压缩包
+func (r *Runtime) logRemovedArchives(entries []store.Entry) {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+		r.logger.Info("removed", "version", entry.Version, "sha256", entry.SHA256, "size", fileop.HumanSize(entry.Size))
+	}
+	r.logger.Info("removed archives", "count", len(entries), "freed", fileop.HumanSize(total))
+}
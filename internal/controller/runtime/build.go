@@ -0,0 +1,183 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/resolver"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/source"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// installSource 从源码构建并安装为指定名称的版本
+// 源码来自 --source 仓库、--from-file 源码压缩包或指定版本的发布源码压缩包
+func (r *Runtime) installSource(spec string) error {
+	if spec != "" && (r.o.sourceRepo != "" || r.o.fromFile != "") {
+		return fmt.Errorf("version %s cannot be used with --source/--from-file, use --name instead", spec)
+	}
+	if spec == "" && r.o.sourceRepo == "" && r.o.fromFile == "" {
+		return fmt.Errorf("version, --source or --from-file is required when applying patches")
+	}
+	if r.o.fromDir != "" {
+		return fmt.Errorf("--from-dir cannot be used when building from source")
+	}
+	name := r.o.name
+	if name == "" {
+		return fmt.Errorf("--name is required when building from source")
+	}
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if fileop.Exist(filepath.Join(r.o.versionsDir, name)) {
+		return fmt.Errorf("version %s already exists, uninstall it before rebuilding", name)
+	}
+
+	// 计算补丁校验和(同时校验补丁文件存在)
+	patches, manifestPatches, err := r.expandPatches()
+	if err != nil {
+		return err
+	}
+
+	// 解析构建使用的已安装版本
+	bootstrap, err := r.bootstrapRoot()
+	if err != nil {
+		return err
+	}
+
+	stage, err := r.newStage(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(stage)
+	}()
+
+	// 获取源码
+	staged := filepath.Join(stage, name)
+	goroot := filepath.Join(staged, "go")
+	manifest := r.newManifest(name, MethodSource, r.o.sourceRepo)
+	manifest.Ref = r.o.ref
+	manifest.Patches = manifestPatches
+	switch {
+	case r.o.sourceRepo != "":
+		r.logger.Info("fetching source", "source", r.o.sourceRepo, "ref", r.o.ref)
+		if err := r.core.Checkout(r.o.sourceRepo, r.o.ref, goroot); err != nil {
+			return err
+		}
+	case r.o.fromFile != "":
+		r.logger.Info("extracting source", "file", r.o.fromFile)
+		if r.o.checksum == "" {
+			r.logger.Warn("no checksum given, the archive will NOT be verified", "file", r.o.fromFile)
+		}
+		if err := r.core.Verify(r.o.fromFile, r.o.checksum); err != nil {
+			return err
+		}
+		if err := r.core.Extract(r.o.fromFile, staged); err != nil {
+			return err
+		}
+		manifest.Source = r.o.fromFile
+		manifest.withFile(r.o.fromFile)
+	default:
+		archive, checksum, err := r.fetchSource(spec, staged)
+		if err != nil {
+			return err
+		}
+		manifest.Source = archive.URL
+		manifest.SHA256 = checksum
+		manifest.Size = archive.Size
+	}
+
+	// 应用补丁并构建
+	if err := r.core.Patch(goroot, patches); err != nil {
+		return err
+	}
+	if err := r.core.Build(goroot, bootstrap); err != nil {
+		return err
+	}
+	if err := writeManifest(staged, manifest); err != nil {
+		return err
+	}
+
+	// 源码构建的版本号不固定(如 devel),不校验版本号
+	if err := r.commitStage(staged, name, ""); err != nil {
+		return err
+	}
+	r.logger.Info("installed", "version", name)
+
+	return r.use(name)
+}
+
+// fetchSource 获取指定版本的发布源码压缩包并解压,返回源码压缩包及其校验和
+func (r *Runtime) fetchSource(spec string, staged string) (*source.Archive, string, error) {
+	version, err := r.ResolveRemote(spec)
+	if err != nil {
+		return nil, "", err
+	}
+	archive, err := r.source.ResolveSource(version)
+	if err != nil {
+		return nil, "", err
+	}
+	checksum, err := r.checksum(archive)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.logger.Info("fetching source", "version", version, "archive", archive.Filename)
+	if err := r.fetch(archive, checksum, staged); err != nil {
+		return nil, "", err
+	}
+	return archive, checksum, nil
+}
+
+// expandPatches 展开补丁文件(支持通配符)并计算校验和
+func (r *Runtime) expandPatches() ([]string, []Patch, error) {
+	patches := make([]string, 0, len(r.o.patches))
+	for _, pattern := range r.o.patches {
+		if !strings.ContainsAny(pattern, "*?[") {
+			patches = append(patches, pattern)
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(matches) == 0 {
+			return nil, nil, fmt.Errorf("no patch matches %s", pattern)
+		}
+		patches = append(patches, matches...)
+	}
+
+	infos := make([]Patch, 0, len(patches))
+	for _, patch := range patches {
+		sum, err := core.HashFile(patch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read patch failed: %w", err)
+		}
+		r.logger.Debug("patch", "file", patch, "sha256", sum)
+		infos = append(infos, Patch{File: filepath.Base(patch), SHA256: sum})
+	}
+	return patches, infos, nil
+}
+
+// bootstrapRoot 获取构建使用的GOROOT_BOOTSTRAP(默认使用已安装的最新稳定版本)
+func (r *Runtime) bootstrapRoot() (string, error) {
+	spec := r.o.bootstrap
+	if spec == "" {
+		spec = resolver.AliasStable
+	}
+	version, err := r.ResolveInstalled(spec)
+	if err != nil {
+		return "", fmt.Errorf("no bootstrap toolchain: %w (install one first or use --bootstrap)", err)
+	}
+
+	// 使用实际路径,避免构建时经过版本目录中的软链接
+	goroot, err := filepath.EvalSymlinks(filepath.Join(r.o.versionsDir, version, "go"))
+	if err != nil {
+		return "", err
+	}
+	r.logger.Debug("bootstrap toolchain", "version", version, "goroot", goroot)
+	return goroot, nil
+}
@@ -1,14 +1,25 @@
 package core
 
-import "github.com/Masterminds/semver"
+import "github.com/justwhenjing/gvm/internal/controller/runtime/version"
 
 // ICore 核心接口
 type ICore interface {
 	// 版本操作
-	ParseVersion(version string) (*semver.Version, error)
-	SortVersions(versions []string) ([]string, error)
-	Download(url string, version string, dst string) (string, error)
+	ParseVersion(name string) (*version.Version, error)
+	SortVersions(names []string) ([]string, error)
+	Download(url string, dst string, checksum string) (string, error)
+	Verify(src string, checksum string) error
 	Extract(src string, dst string) error
+	DownloadExtract(url string, dst string, checksum string) error
+	Validate(goroot string, expect string) error
+
+	// 源码构建
+	Checkout(src string, ref string, dst string) error
+	Patch(goroot string, patches []string) error
+	Build(goroot string, bootstrap string) error
+
+	// 执行程序
+	Exec(bin string, args []string, env []string) error
 
 	// 缓存
 	LoadCache() ([]string, error)
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// buildLogTail 构建失败时显示的输出行数
+const buildLogTail = 20
+
+// Checkout 获取Go源码到dst(git仓库按ref检出,本地非git目录直接复制)
+func (c *Core) Checkout(src string, ref string, dst string) error {
+	c.logger.Debug("checkout source", "src", src, "ref", ref, "dst", dst)
+
+	// 本地非git目录直接复制
+	if info, err := os.Stat(src); err == nil && info.IsDir() && !fileop.Exist(filepath.Join(src, ".git")) {
+		if ref != "" {
+			return fmt.Errorf("source %s is not a git repository, ref %s cannot be used", src, ref)
+		}
+		return fileop.CopyDir(src, dst)
+	}
+
+	// 未指定ref时浅克隆默认分支
+	if ref == "" {
+		return c.git("", "clone", "--depth", "1", src, dst)
+	}
+
+	// 分支或标签可直接浅克隆,否则完整克隆后检出(如提交)
+	if err := c.git("", "clone", "--depth", "1", "--branch", ref, src, dst); err == nil {
+		return nil
+	}
+	c.logger.Debug("shallow clone failed, fall back to full clone", "ref", ref)
+	_ = os.RemoveAll(dst)
+	if err := c.git("", "clone", "--no-checkout", src, dst); err != nil {
+		return err
+	}
+	if err := c.git(dst, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		return fmt.Errorf("ref %s not found in %s", ref, src)
+	}
+	return c.git(dst, "checkout", "--detach", ref)
+}
+
+// Build 使用bootstrap版本执行 make 脚本构建goroot
+func (c *Core) Build(goroot string, bootstrap string) error {
+	srcDir := filepath.Join(goroot, "src")
+	script := filepath.Join(srcDir, MakeScript)
+	if _, err := os.Stat(script); err != nil {
+		return fmt.Errorf("invalid go source %s: %w", goroot, err)
+	}
+	c.logger.Info("building", "goroot", goroot, "bootstrap", bootstrap)
+
+	// #nosec G204
+	cmd := exec.Command(script)
+	cmd.Dir = srcDir
+	cmd.Env = append(filterEnv(os.Environ(), "GOROOT", "GOPATH", "GOFLAGS", "GOTOOLCHAIN"),
+		"GOROOT_BOOTSTRAP="+bootstrap, "GOTOOLCHAIN=local")
+
+	// 详细模式下实时输出,否则失败时显示最后几行
+	var output bytes.Buffer
+	if c.o.verbose {
+		cmd.Stdout = io.MultiWriter(os.Stdout, &output)
+		cmd.Stderr = io.MultiWriter(os.Stderr, &output)
+	} else {
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("build %s failed: %w\n%s", goroot, err, tail(output.String(), buildLogTail))
+	}
+	return nil
+}
+
+// Patch 按顺序应用补丁到goroot(任一补丁失败时返回错误)
+func (c *Core) Patch(goroot string, patches []string) error {
+	for i, patch := range patches {
+		fp, err := filepath.Abs(patch)
+		if err != nil {
+			return err
+		}
+		// 限制git仓库查找范围,非git目录时按普通补丁应用
+		err = c.gitEnv(goroot, []string{"GIT_CEILING_DIRECTORIES=" + filepath.Dir(goroot)}, "apply", "--whitespace=nowarn", fp)
+		if err != nil {
+			return fmt.Errorf("apply patch %d/%d %s failed: %w", i+1, len(patches), patch, err)
+		}
+		c.logger.Info("applied patch", "patch", patch)
+	}
+	return nil
+}
+
+// git 执行git命令
+func (c *Core) git(dir string, args ...string) error {
+	return c.gitEnv(dir, nil, args...)
+}
+
+// gitEnv 使用额外的环境变量执行git命令
+func (c *Core) gitEnv(dir string, env []string, args ...string) error {
+	c.logger.Debug("run git", "dir", dir, "args", args)
+
+	// #nosec G204
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// filterEnv 去除指定的环境变量
+func filterEnv(env []string, keys ...string) []string {
+	result := make([]string, 0, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		drop := false
+		for _, key := range keys {
+			if strings.EqualFold(name, key) {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
+
+// tail 获取文本的最后n行
+func tail(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
+
+	"github.com/justwhenjing/gvm/internal/util/fileop"
 )
 
 type Cache struct {
@@ -53,23 +54,38 @@ func (c *Core) SaveCache(versions []string) error {
 		return nil
 	}
 
-	// 覆盖写入缓存文件
-	if err := os.MkdirAll(filepath.Dir(c.o.cacheFile), 0755); err != nil {
-		return err
-	}
-	fObj, err := os.OpenFile(c.o.cacheFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	// 持有缓存锁,避免并发写入
+	lock, err := fileop.NewLock(c.o.cacheFile+".lock", c.o.lockTimeout, func(holder string) {
+		c.logger.Info("waiting for another gvm process", "pid", holder, "lock", c.o.cacheFile+".lock")
+	})
 	if err != nil {
 		return err
 	}
 	defer func() {
-		_ = fObj.Close()
+		_ = lock.Unlock()
 	}()
 
+	// 写入临时文件后重命名覆盖缓存文件
+	tmp := c.o.cacheFile + ".tmp"
+	fObj, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
 	encoder := json.NewEncoder(fObj)
 	encoder.SetIndent("", "  ")
 	cache := &Cache{
 		Timestamp: time.Now().Format(time.RFC3339),
 		Versions:  versions,
 	}
-	return encoder.Encode(cache)
+	if err := encoder.Encode(cache); err != nil {
+		_ = fObj.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := fObj.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, c.o.cacheFile)
 }
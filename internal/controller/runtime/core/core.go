@@ -7,14 +7,13 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
-	"github.com/Masterminds/semver"
 	"github.com/schollz/progressbar/v3"
 
 	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
 	"github.com/justwhenjing/gvm/internal/util/httpcli"
 	"github.com/justwhenjing/gvm/internal/util/log"
 )
@@ -31,9 +30,12 @@ type Core struct {
 
 func NewCore(logger log.ILog, conf *config.Config, opts ...OptionFunc) ICore {
 	o := &Option{
-		cacheFile: filepath.Join(conf.RootDir, "cache.json"),
-		ttl:       conf.CacheTTL,
-		verbose:   conf.Verbose,
+		cacheFile:   filepath.Join(conf.RootDir, "cache.json"),
+		ttl:         conf.CacheTTL,
+		lockTimeout: conf.LockTimeout,
+		retries:     conf.Retries,
+		concurrency: conf.Concurrency,
+		verbose:     conf.Verbose,
 	}
 	o.Apply(opts)
 
@@ -43,76 +45,61 @@ func NewCore(logger log.ILog, conf *config.Config, opts ...OptionFunc) ICore {
 	}
 }
 
-func (c *Core) ParseVersion(version string) (*semver.Version, error) {
-	if version == "" || version == NoneVersion {
+// ParseVersion 解析版本号
+func (c *Core) ParseVersion(name string) (*version.Version, error) {
+	if name == "" || name == NoneVersion {
 		return nil, fmt.Errorf("no version provided")
 	}
 
-	semverVersion, err := semver.NewVersion(FormatVersion(version))
+	v, err := version.Parse(FormatVersion(name))
 	if err != nil {
-		return nil, fmt.Errorf("parse version %s failed: %w", version, err)
+		return nil, fmt.Errorf("parse version %s failed: %w", name, err)
 	}
-	return semverVersion, nil
+	return v, nil
 }
 
-// SortVersions 排序版本号
-func (c *Core) SortVersions(versions []string) ([]string, error) {
-	result := make([]string, 0)
-	semverVersions := make([]*semver.Version, 0)
-	rcBetaVersions := make([]string, 0)
-
-	// 分解beta/rc版本和语义化版本
-	for _, version := range versions {
-		if IsBetaOrRC(version) {
-			rcBetaVersions = append(rcBetaVersions, version)
-		} else {
-			// 解析为语义化版本
-			v, err := semver.NewVersion(version)
-			if err != nil {
-				return nil, fmt.Errorf("semver version failed: %w", err)
-			}
-			semverVersions = append(semverVersions, v)
+// SortVersions 排序版本号(beta < rc < 正式版本,无法解析的版本按名称排在最后)
+func (c *Core) SortVersions(names []string) ([]string, error) {
+	parsed := make([]*version.Version, 0, len(names))
+	index := make(map[*version.Version]string, len(names))
+	others := make([]string, 0)
+
+	for _, name := range names {
+		v, err := version.Parse(name)
+		if err != nil {
+			others = append(others, name)
+			continue
 		}
+		parsed = append(parsed, v)
+		index[v] = name
 	}
 
-	// 处理语义化版本
-	sort.Sort(semver.Collection(semverVersions))
-	for _, versionSemantic := range semverVersions {
-		version := versionSemantic.String()
-		// For versions < 1.21.0, display as major.minor
-		//
-		if versionSemantic.Major() == 1 && versionSemantic.Minor() < 21 && versionSemantic.Patch() == 0 {
-			version = fmt.Sprintf("%d.%d", versionSemantic.Major(), versionSemantic.Minor())
-		}
-		result = append(result, version)
+	version.Sort(parsed)
+	result := make([]string, 0, len(names))
+	for _, v := range parsed {
+		result = append(result, index[v])
 	}
 
-	// 处理beta/rc版本
-	result = append(result, rcBetaVersions...)
+	sort.Strings(others)
+	result = append(result, others...)
 	return result, nil
 }
 
 // FormatVersion 格式化版本号
-func FormatVersion(version string) string {
+func FormatVersion(name string) string {
 	// Remove @latest and @dev-latest suffixes
-	version = strings.TrimSuffix(version, "@latest")
-	version = strings.TrimSuffix(version, "@dev-latest")
+	name = strings.TrimSuffix(name, "@latest")
+	name = strings.TrimSuffix(name, "@dev-latest")
 
 	// Remove .x and x suffixes
-	version = strings.TrimSuffix(version, ".x")
-	version = strings.TrimSuffix(version, "x")
-	return version
+	name = strings.TrimSuffix(name, ".x")
+	name = strings.TrimSuffix(name, "x")
+	return name
 }
 
 // IsBetaOrRC 判断是否是beta或rc版本
-func IsBetaOrRC(version string) bool {
-	return len(MatchBetaOrRC(version)) > 0
-}
-
-// MatchBetaOrRC 匹配beta或rc版本
-func MatchBetaOrRC(version string) []string {
-	re := regexp.MustCompile("beta.*|rc.*")
-	return re.FindAllString(version, -1)
+func IsBetaOrRC(name string) bool {
+	return version.IsPrerelease(name)
 }
 
 // NotSupportedVersion 不支持的版本
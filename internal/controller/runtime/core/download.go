@@ -0,0 +1,451 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/justwhenjing/gvm/internal/util/httpcli"
+)
+
+const (
+	// 下载重试等待时间
+	retryBaseWait = time.Second
+	retryMaxWait  = 30 * time.Second
+
+	// 分块下载的最小分块大小
+	minChunkSize = 4 << 20
+)
+
+// sleep 重试等待(测试时替换)
+var sleep = time.Sleep
+
+// statusError 非预期的响应状态码,记录响应的 Retry-After 供重试等待
+type statusError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// withRetryAfter 附加响应头中的 Retry-After
+func withRetryAfter(header http.Header, err error) error {
+	return &statusError{err: err, retryAfter: parseRetryAfter(header.Get("Retry-After"))}
+}
+
+// partMeta 未完成下载(.part)的校验信息,用于确认续传的是同一个文件
+type partMeta struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Download 下载压缩包(checksum不为空时校验sha256)
+//
+// 下载内容先写入 <name>.part,中断后重试或再次执行时通过 HTTP Range 续传,
+// 续传时使用 If-Range 校验 ETag/Last-Modified,文件变化时重新下载
+func (c *Core) Download(downloadURL string, destFolder string, checksum string) (string, error) {
+	// 1) 创建父目录
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return "", err
+	}
+	tarName := path.Base(u.Path)
+	if err := os.MkdirAll(destFolder, 0755); err != nil {
+		return "", err
+	}
+
+	// 2) 下载版本(服务端支持Range时分块并发下载,否则单连接下载并在中断后续传)
+	dest := filepath.Join(destFolder, tarName)
+	part := dest + ".part"
+	c.logger.Debug("download", "src", downloadURL, "dest", dest)
+
+	client := httpcli.NewClient(
+		httpcli.WithDebug(c.o.verbose),
+	)
+
+	var actual string
+	if resumable(downloadURL, part) {
+		// 已有可续传的part文件时单连接续传,避免分块下载丢弃已下载内容
+		actual, err = c.fetchStream(client, downloadURL, part)
+	} else if meta, size := c.rangeSize(client, downloadURL); size > 0 {
+		c.logger.Debug("download in chunks", "size", size, "concurrency", c.o.concurrency)
+		actual, err = c.fetchChunks(client, downloadURL, meta, part, size)
+	} else {
+		actual, err = c.fetchStream(client, downloadURL, part)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// 3) 校验
+	if checksum != "" && !strings.EqualFold(actual, checksum) {
+		removePart(part)
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, actual %s", tarName, checksum, actual)
+	}
+	c.logger.Debug("download checksum", "sha256", actual)
+
+	if err := os.Rename(part, dest); err != nil {
+		return "", err
+	}
+	_ = os.Remove(part + ".json")
+
+	c.logger.Info("download completed")
+	return dest, nil
+}
+
+// fetchStream 单连接下载,中断后按 Retry-After 或指数退避重试续传,返回sha256
+func (c *Core) fetchStream(client *httpcli.Client, downloadURL string, part string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		hasher, err := c.fetch(client, downloadURL, part)
+		if err == nil {
+			return hex.EncodeToString(hasher.Sum(nil)), nil
+		}
+		if attempt >= c.o.retries {
+			return "", fmt.Errorf("download %s failed after %d attempts: %w", downloadURL, attempt+1, err)
+		}
+
+		wait := retryWait(attempt, err)
+		c.logger.Warn("download interrupted, retrying", "error", err, "wait", wait, "attempt", attempt+1)
+		sleep(wait)
+	}
+}
+
+// fetch 下载(或续传)到part文件,返回整个文件的sha256
+func (c *Core) fetch(client *httpcli.Client, downloadURL string, part string) (hash.Hash, error) {
+	// 1) 检查是否可以续传
+	meta := loadPartMeta(part)
+	var offset int64
+	if info, err := os.Stat(part); err == nil && meta != nil && meta.URL == downloadURL && meta.validator() != "" {
+		offset = info.Size()
+	}
+
+	header := make(map[string]string)
+	if offset > 0 {
+		header["Range"] = fmt.Sprintf("bytes=%d-", offset)
+		header["If-Range"] = meta.validator()
+	}
+
+	resp, err := client.GetStream(downloadURL, header)
+	if err != nil {
+		return nil, err
+	}
+	body := resp.RawBody()
+	defer func() {
+		_ = body.Close()
+	}()
+
+	// 2) 根据状态码确定写入位置
+	switch resp.StatusCode() {
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header().Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			removePart(part)
+			return nil, fmt.Errorf("unexpected content range %q", resp.Header().Get("Content-Range"))
+		}
+		c.logger.Info("resume download", "offset", offset)
+	case http.StatusOK:
+		// 不支持续传或文件已变化,重新下载
+		if offset > 0 {
+			c.logger.Info("remote file changed or range not supported, restart download")
+		}
+		offset = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		removePart(part)
+		return nil, fmt.Errorf("%s returned status code %d", downloadURL, resp.StatusCode())
+	default:
+		return nil, withRetryAfter(resp.Header(), fmt.Errorf("%s returned status code %d", downloadURL, resp.StatusCode()))
+	}
+
+	// 3) 记录校验信息
+	meta = &partMeta{
+		URL:          downloadURL,
+		ETag:         resp.Header().Get("ETag"),
+		LastModified: resp.Header().Get("Last-Modified"),
+	}
+	if err := meta.save(part); err != nil {
+		return nil, err
+	}
+
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 {
+		flag = os.O_CREATE | os.O_RDWR
+	}
+	// #nosec G304
+	fObj, err := os.OpenFile(part, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fObj.Close()
+	}()
+
+	// 4) 续传时先计算已下载部分的校验和
+	hasher := sha256.New()
+	if offset > 0 {
+		if _, err := io.CopyN(hasher, fObj, offset); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5) 写入文件的同时计算校验和并显示进度
+	total := int64(-1)
+	if resp.RawResponse.ContentLength >= 0 {
+		total = offset + resp.RawResponse.ContentLength
+	}
+	bar := progressbar.DefaultBytes(total, "Downloading")
+	_ = bar.Set64(offset)
+
+	written, err := io.Copy(io.MultiWriter(fObj, hasher, bar), body)
+	if err != nil {
+		return nil, err
+	}
+	if total >= 0 && offset+written != total {
+		return nil, fmt.Errorf("incomplete download: %d of %d bytes", offset+written, total)
+	}
+	return hasher, nil
+}
+
+// rangeSize 服务端支持Range、提供校验值且文件足够大时返回校验信息及文件大小,否则返回0
+//
+// 没有 ETag/Last-Modified 时无法通过 If-Range 确认各分块来自同一文件,不使用分块下载
+func (c *Core) rangeSize(client *httpcli.Client, downloadURL string) (*partMeta, int64) {
+	if c.o.concurrency <= 1 {
+		return nil, 0
+	}
+
+	resp, err := client.Head(downloadURL)
+	if err != nil || resp.StatusCode() != http.StatusOK {
+		return nil, 0
+	}
+	if resp.Header().Get("Accept-Ranges") != "bytes" {
+		return nil, 0
+	}
+	size, err := strconv.ParseInt(resp.Header().Get("Content-Length"), 10, 64)
+	if err != nil || size < minChunkSize*2 {
+		return nil, 0
+	}
+
+	meta := &partMeta{
+		URL:          downloadURL,
+		ETag:         resp.Header().Get("ETag"),
+		LastModified: resp.Header().Get("Last-Modified"),
+	}
+	if meta.validator() == "" {
+		return nil, 0
+	}
+	return meta, size
+}
+
+// fetchChunks 按字节范围分块并发下载到part文件,返回sha256
+//
+// 失败时保留从文件开头连续下载完成的部分作为可续传的part文件,再次下载时单连接续传
+func (c *Core) fetchChunks(client *httpcli.Client, downloadURL string, meta *partMeta, part string, size int64) (string, error) {
+	removePart(part)
+	validator := meta.validator()
+	// #nosec G304
+	fObj, err := os.OpenFile(part, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return "", err
+	}
+	if err := fObj.Truncate(size); err != nil {
+		_ = fObj.Close()
+		return "", err
+	}
+
+	bar := progressbar.DefaultBytes(size, "Downloading")
+	chunk := (size + int64(c.o.concurrency) - 1) / int64(c.o.concurrency)
+	errs := make([]error, c.o.concurrency)
+	done := make([]int64, c.o.concurrency)
+
+	var wg sync.WaitGroup
+	for i := range c.o.concurrency {
+		start := int64(i) * chunk
+		end := min(start+chunk, size) - 1
+		if start > end {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done[i], errs[i] = c.fetchChunk(client, downloadURL, validator, fObj, start, end, bar)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		// 计算从文件开头连续完成的字节数
+		var prefix int64
+		for i := range c.o.concurrency {
+			prefix += done[i]
+			if done[i] < min(chunk, size-int64(i)*chunk) {
+				break
+			}
+		}
+		c.keepPart(fObj, meta, part, prefix)
+		return "", err
+	}
+	if err := fObj.Close(); err != nil {
+		removePart(part)
+		return "", err
+	}
+	return HashFile(part)
+}
+
+// keepPart 截断part文件到已连续下载的长度并记录校验信息,供下次续传
+func (c *Core) keepPart(fObj *os.File, meta *partMeta, part string, size int64) {
+	err := fObj.Truncate(size)
+	if closeErr := fObj.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > 0 {
+		err = meta.save(part)
+	}
+	if err != nil || size == 0 {
+		removePart(part)
+		return
+	}
+	c.logger.Debug("keep partial download", "part", part, "size", size)
+}
+
+// fetchChunk 下载一个分块,中断后按 Retry-After 或指数退避重试剩余部分,返回已写入的字节数
+func (c *Core) fetchChunk(client *httpcli.Client, downloadURL string, validator string,
+	fObj *os.File, start int64, end int64, bar io.Writer) (int64, error) {
+	var total int64
+	for attempt := 0; ; attempt++ {
+		written, err := c.fetchRange(client, downloadURL, validator, fObj, start+total, end, bar)
+		total += written
+		if err == nil {
+			return total, nil
+		}
+		if attempt >= c.o.retries {
+			return total, fmt.Errorf("download bytes %d-%d failed after %d attempts: %w", start+total, end, attempt+1, err)
+		}
+
+		wait := retryWait(attempt, err)
+		c.logger.Debug("download chunk interrupted, retrying", "start", start+total, "end", end, "error", err, "wait", wait)
+		sleep(wait)
+	}
+}
+
+// fetchRange 下载 [start, end] 字节范围并写入文件对应位置,返回写入的字节数
+func (c *Core) fetchRange(client *httpcli.Client, downloadURL string, validator string,
+	fObj *os.File, start int64, end int64, bar io.Writer) (int64, error) {
+	header := map[string]string{"Range": fmt.Sprintf("bytes=%d-%d", start, end)}
+	if validator != "" {
+		header["If-Range"] = validator
+	}
+
+	resp, err := client.GetStream(downloadURL, header)
+	if err != nil {
+		return 0, err
+	}
+	body := resp.RawBody()
+	defer func() {
+		_ = body.Close()
+	}()
+
+	// 文件变化时服务端返回200,分块无法拼接
+	if resp.StatusCode() != http.StatusPartialContent ||
+		!strings.HasPrefix(resp.Header().Get("Content-Range"), fmt.Sprintf("bytes %d-", start)) {
+		return 0, withRetryAfter(resp.Header(),
+			fmt.Errorf("%s returned status code %d for range %d-%d", downloadURL, resp.StatusCode(), start, end))
+	}
+
+	written, err := io.Copy(io.MultiWriter(io.NewOffsetWriter(fObj, start), bar), io.LimitReader(body, end-start+1))
+	if err != nil {
+		return written, err
+	}
+	if written != end-start+1 {
+		return written, fmt.Errorf("incomplete range %d-%d: %d bytes", start, end, written)
+	}
+	return written, nil
+}
+
+// retryWait 第attempt次重试的等待时间(响应带 Retry-After 时优先使用,否则指数退避)
+func retryWait(attempt int, err error) time.Duration {
+	var se *statusError
+	if errors.As(err, &se) && se.retryAfter > 0 {
+		return se.retryAfter
+	}
+	return min(retryBaseWait<<attempt, retryMaxWait)
+}
+
+// parseRetryAfter 解析 Retry-After 响应头(秒数或HTTP日期),无效时返回0
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return min(time.Duration(seconds)*time.Second, retryMaxWait)
+	}
+	if at, err := http.ParseTime(value); err == nil && time.Until(at) > 0 {
+		return min(time.Until(at), retryMaxWait)
+	}
+	return 0
+}
+
+// validator 续传校验值(优先ETag)
+func (m *partMeta) validator() string {
+	if m.ETag != "" && !strings.HasPrefix(m.ETag, "W/") {
+		return m.ETag
+	}
+	return m.LastModified
+}
+
+func (m *partMeta) save(part string) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(part+".json", content, 0644)
+}
+
+// resumable 是否存在可续传的part文件(同一地址且有校验值)
+func resumable(downloadURL string, part string) bool {
+	info, err := os.Stat(part)
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	meta := loadPartMeta(part)
+	return meta != nil && meta.URL == downloadURL && meta.validator() != ""
+}
+
+func loadPartMeta(part string) *partMeta {
+	// #nosec G304
+	content, err := os.ReadFile(part + ".json")
+	if err != nil {
+		return nil
+	}
+	meta := &partMeta{}
+	if err := json.Unmarshal(content, meta); err != nil {
+		return nil
+	}
+	return meta
+}
+
+func removePart(part string) {
+	_ = os.Remove(part)
+	_ = os.Remove(part + ".json")
+}
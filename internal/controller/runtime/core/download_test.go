@@ -0,0 +1,203 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+// rangeServer 支持Range的测试服务,failRanges为true时拒绝非文件开头的分块请求
+type rangeServer struct {
+	data       []byte
+	etag       string
+	failRanges atomic.Bool
+	ranges     atomic.Int32 // Range请求次数
+}
+
+func (s *rangeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	start, end := int64(0), int64(len(s.data)-1)
+	status := http.StatusOK
+	if rng := r.Header.Get("Range"); rng != "" && r.Method == http.MethodGet {
+		s.ranges.Add(1)
+		bounds := strings.SplitN(strings.TrimPrefix(rng, "bytes="), "-", 2)
+		start, _ = strconv.ParseInt(bounds[0], 10, 64)
+		if bounds[1] != "" {
+			end, _ = strconv.ParseInt(bounds[1], 10, 64)
+		}
+		if s.failRanges.Load() && start > 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		status = http.StatusPartialContent
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(s.data)))
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(end-start+1, 10))
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(s.data[start : end+1])
+	}
+}
+
+func newTestCore(t *testing.T, retries int, concurrency int) *Core {
+	t.Helper()
+	logger, err := log.NewLogger(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Core{logger: logger, o: &Option{retries: retries, concurrency: concurrency}}
+}
+
+func testData() ([]byte, string) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), (minChunkSize*2+1024)/16)
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:])
+}
+
+func TestDownloadChunksKeepResumablePart(t *testing.T) {
+	data, checksum := testData()
+	srv := &rangeServer{data: data, etag: `"v1"`}
+	srv.failRanges.Store(true)
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	c := newTestCore(t, 0, 2)
+	dir := t.TempDir()
+	downloadURL := ts.URL + "/go.tar.gz"
+	part := filepath.Join(dir, "go.tar.gz.part")
+
+	// 1) 第二个分块失败,保留第一个分块作为可续传的part文件
+	if _, err := c.Download(downloadURL, dir, checksum); err == nil {
+		t.Fatal("Download() succeeded, want error")
+	}
+	info, err := os.Stat(part)
+	if err != nil {
+		t.Fatalf("part file not kept: %v", err)
+	}
+	chunk := (int64(len(data)) + 1) / 2
+	if info.Size() != chunk {
+		t.Fatalf("part size = %d, want %d", info.Size(), chunk)
+	}
+	if !resumable(downloadURL, part) {
+		t.Fatal("part file is not resumable")
+	}
+
+	// 2) 再次下载时单连接从断点续传
+	srv.failRanges.Store(false)
+	srv.ranges.Store(0)
+	dest, err := c.Download(downloadURL, dir, checksum)
+	if err != nil {
+		t.Fatalf("Download() error: %v", err)
+	}
+	if got := srv.ranges.Load(); got != 1 {
+		t.Errorf("range requests = %d, want 1", got)
+	}
+	content, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, data) {
+		t.Error("downloaded content mismatch")
+	}
+	if _, err := os.Stat(part + ".json"); !os.IsNotExist(err) {
+		t.Errorf("part meta not removed: %v", err)
+	}
+}
+
+func TestDownloadWithoutValidatorSkipsChunks(t *testing.T) {
+	data, checksum := testData()
+	srv := &rangeServer{data: data}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	c := newTestCore(t, 0, 4)
+	dest, err := c.Download(ts.URL+"/go.tar.gz", t.TempDir(), checksum)
+	if err != nil {
+		t.Fatalf("Download() error: %v", err)
+	}
+	if got := srv.ranges.Load(); got != 0 {
+		t.Errorf("range requests = %d, want 0", got)
+	}
+	content, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, data) {
+		t.Error("downloaded content mismatch")
+	}
+}
+
+func TestDownloadHonorsRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "seconds", retryAfter: "7", want: 7 * time.Second},
+		{name: "capped", retryAfter: "3600", want: retryMaxWait},
+		{name: "missing", retryAfter: "", want: retryBaseWait},
+		{name: "invalid", retryAfter: "soon", want: retryBaseWait},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte("go archive")
+			sum := sha256.Sum256(data)
+
+			// 首次请求返回503,之后正常返回
+			var requests atomic.Int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write(data)
+			}))
+			defer ts.Close()
+
+			var waits []time.Duration
+			sleep = func(d time.Duration) { waits = append(waits, d) }
+			defer func() { sleep = time.Sleep }()
+
+			c := newTestCore(t, 1, 1)
+			if _, err := c.Download(ts.URL+"/go.tar.gz", t.TempDir(), hex.EncodeToString(sum[:])); err != nil {
+				t.Fatalf("Download() error: %v", err)
+			}
+			if len(waits) != 1 || waits[0] != tt.want {
+				t.Errorf("waits = %v, want [%v]", waits, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)); got <= 0 || got > 10*time.Second {
+		t.Errorf("parseRetryAfter(date) = %v, want (0, 10s]", got)
+	}
+	if got := parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)); got != 0 {
+		t.Errorf("parseRetryAfter(past date) = %v, want 0", got)
+	}
+	if got := parseRetryAfter("-1"); got != 0 {
+		t.Errorf("parseRetryAfter(-1) = %v, want 0", got)
+	}
+}
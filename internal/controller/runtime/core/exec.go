@@ -0,0 +1,12 @@
+package core
+
+import "fmt"
+
+// ExitError 子进程以非零状态码退出(gvm以相同状态码退出)
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
@@ -0,0 +1,14 @@
+//go:build linux
+// +build linux
+
+package core
+
+import "syscall"
+
+// Exec 使用指定程序替换当前进程(成功时不返回)
+func (c *Core) Exec(bin string, args []string, env []string) error {
+	c.logger.Debug("exec", "bin", bin, "args", args)
+
+	// #nosec G204
+	return syscall.Exec(bin, append([]string{bin}, args...), env)
+}
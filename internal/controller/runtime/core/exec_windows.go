@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package core
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Exec 执行指定程序并等待退出(windows不支持替换进程,返回子进程的退出状态码)
+func (c *Core) Exec(bin string, args []string, env []string) error {
+	c.logger.Debug("exec", "bin", bin, "args", args)
+
+	// #nosec G204
+	cmd := exec.Command(bin, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
@@ -4,9 +4,12 @@ import "time"
 
 // TODO 如何优化一下
 type Option struct {
-	cacheFile string        // 缓存文件
-	ttl       time.Duration // 缓存过期时间
-	verbose   bool          // 是否显示详细信息
+	cacheFile   string        // 缓存文件
+	ttl         time.Duration // 缓存过期时间
+	lockTimeout time.Duration // 等待缓存锁超时时间
+	retries     int           // 下载失败重试次数
+	concurrency int           // 分块下载并发数
+	verbose     bool          // 是否显示详细信息
 }
 
 func (o *Option) Apply(opts []OptionFunc) {
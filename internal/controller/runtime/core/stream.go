@@ -0,0 +1,232 @@
+package core
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/schollz/progressbar/v3"
+
+	"github.com/justwhenjing/gvm/internal/util/httpcli"
+)
+
+// DownloadExtract 边下载边解压tar.gz压缩包到dst(不落盘压缩包)
+//
+// 响应内容同时写入sha256计算,下载结束后校验不通过则清理dst并返回错误,
+// 调用方需在校验通过后才能使用dst中的内容
+func (c *Core) DownloadExtract(downloadURL string, dst string, checksum string) error {
+	if !strings.HasSuffix(downloadURL, ".tar.gz") {
+		return fmt.Errorf("stream extraction only supports .tar.gz archives: %s", downloadURL)
+	}
+	c.logger.Debug("download and extract", "src", downloadURL, "dst", dst)
+
+	client := httpcli.NewClient(
+		httpcli.WithDebug(c.o.verbose),
+	)
+
+	// 中断后无法续传,清理后重新下载
+	for attempt := 0; ; attempt++ {
+		actual, err := c.streamExtract(client, downloadURL, dst)
+		if err == nil {
+			if checksum != "" && !strings.EqualFold(actual, checksum) {
+				_ = os.RemoveAll(dst)
+				return fmt.Errorf("checksum mismatch for %s: expected %s, actual %s",
+					filepath.Base(downloadURL), checksum, actual)
+			}
+			c.logger.Debug("download checksum", "sha256", actual)
+			c.logger.Info("download and extract completed")
+			return nil
+		}
+
+		_ = os.RemoveAll(dst)
+		if attempt >= c.o.retries {
+			return fmt.Errorf("download %s failed after %d attempts: %w", downloadURL, attempt+1, err)
+		}
+		wait := retryWait(attempt, err)
+		c.logger.Warn("download interrupted, retrying", "error", err, "wait", wait, "attempt", attempt+1)
+		sleep(wait)
+	}
+}
+
+// streamExtract 下载并解压一次,返回整个压缩包的sha256
+func (c *Core) streamExtract(client *httpcli.Client, downloadURL string, dst string) (string, error) {
+	resp, err := client.GetStream(downloadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	body := resp.RawBody()
+	defer func() {
+		_ = body.Close()
+	}()
+
+	if resp.StatusCode() != http.StatusOK {
+		return "", withRetryAfter(resp.Header(), fmt.Errorf("%s returned status code %d", downloadURL, resp.StatusCode()))
+	}
+
+	hasher := sha256.New()
+	bar := progressbar.DefaultBytes(resp.RawResponse.ContentLength, "Downloading")
+	tee := io.TeeReader(body, io.MultiWriter(hasher, bar))
+
+	gz, err := gzip.NewReader(tee)
+	if err != nil {
+		return "", err
+	}
+	// 压缩包为单个gzip流,尾部多余内容交由校验和检查
+	gz.Multistream(false)
+	if err := untar(tar.NewReader(gz), dst); err != nil {
+		return "", err
+	}
+
+	// 读取剩余内容(tar结束块及gzip尾部),保证校验和覆盖整个压缩包
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// untar 解压tar流到dst
+//
+// 拒绝越界的路径及链接目标,且不经由已有的链接写入(先删除已存在的条目再创建)
+func untar(tr *tar.Reader, dst string) error {
+	dst = filepath.Clean(dst)
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// #nosec G305
+		target := filepath.Join(dst, hdr.Name)
+		if !within(dst, target) {
+			return fmt.Errorf("illegal path in archive: %s", hdr.Name)
+		}
+		if err := checkParents(dst, target); err != nil {
+			return err
+		}
+
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if fi, err := os.Lstat(target); err == nil && !fi.IsDir() {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+			if err := os.MkdirAll(target, mode.Perm()|0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, mode.Perm()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// 链接目标按链接所在目录解析,必须位于dst内
+			if filepath.IsAbs(hdr.Linkname) || !within(dst, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+				return fmt.Errorf("illegal symlink in archive: %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if err := prepareEntry(target); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			// #nosec G305
+			source := filepath.Join(dst, hdr.Linkname)
+			if !within(dst, source) || source == dst {
+				return fmt.Errorf("illegal hard link in archive: %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if err := checkParents(dst, source); err != nil {
+				return err
+			}
+			if err := prepareEntry(target); err != nil {
+				return err
+			}
+			if err := os.Link(source, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// within path是否位于dst内(dst需为Clean后的路径)
+func within(dst string, path string) bool {
+	return path == dst || strings.HasPrefix(path, dst+string(os.PathSeparator))
+}
+
+// checkParents 确认target在dst内已存在的各级父目录均不是符号链接,避免经由链接写到dst之外
+func checkParents(dst string, target string) error {
+	rel, err := filepath.Rel(dst, filepath.Dir(target))
+	if err != nil || rel == "." {
+		return err
+	}
+
+	current := dst
+	for _, name := range strings.Split(rel, string(os.PathSeparator)) {
+		current = filepath.Join(current, name)
+		fi, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("illegal path in archive: %s is a symlink", current)
+		}
+	}
+	return nil
+}
+
+// prepareEntry 创建父目录并删除已存在的非目录条目(文件、符号链接或硬链接)
+func prepareEntry(target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	fi, err := os.Lstat(target)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("cannot replace directory %s", target)
+	}
+	return os.Remove(target)
+}
+
+// writeFile 写入解压文件(O_EXCL保证不会经由已有链接写入)
+func writeFile(target string, r io.Reader, perm os.FileMode) error {
+	if err := prepareEntry(target); err != nil {
+		return err
+	}
+	// #nosec G304
+	fObj, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	// #nosec G110
+	if _, err := io.Copy(fObj, r); err != nil {
+		_ = fObj.Close()
+		return err
+	}
+	return fObj.Close()
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// entry 测试用tar条目
+type entry struct {
+	name     string
+	typeflag byte
+	linkname string
+	body     string
+}
+
+func buildTar(t *testing.T, entries []entry) *tar.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Linkname: e.linkname, Mode: 0644, Size: int64(len(e.body))}
+		if e.typeflag == tar.TypeDir {
+			hdr.Mode = 0755
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return tar.NewReader(&buf)
+}
+
+func TestUntarRejectsHostileEntries(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	tests := []struct {
+		name    string
+		entries func(outside string) []entry
+		escaped string // 不应被写入的dst外文件(相对outside)
+	}{
+		{
+			name: "path traversal",
+			entries: func(string) []entry {
+				return []entry{{name: "go/../../victim.txt", typeflag: tar.TypeReg, body: "pwned"}}
+			},
+			escaped: "victim.txt",
+		},
+		{
+			name: "hardlink outside",
+			entries: func(string) []entry {
+				return []entry{
+					{name: "go/evil", typeflag: tar.TypeLink, linkname: "../victim.txt"},
+					{name: "go/evil", typeflag: tar.TypeReg, body: "pwned"},
+				}
+			},
+			escaped: "victim.txt",
+		},
+		{
+			name: "absolute symlink",
+			entries: func(outside string) []entry {
+				return []entry{
+					{name: "go/sl", typeflag: tar.TypeSymlink, linkname: outside},
+					{name: "go/sl/escaped.txt", typeflag: tar.TypeReg, body: "pwned"},
+				}
+			},
+			escaped: "escaped.txt",
+		},
+		{
+			name: "relative symlink outside",
+			entries: func(string) []entry {
+				return []entry{
+					{name: "go/sl", typeflag: tar.TypeSymlink, linkname: "../.."},
+					{name: "go/sl/escaped.txt", typeflag: tar.TypeReg, body: "pwned"},
+				}
+			},
+			escaped: "escaped.txt",
+		},
+		{
+			name: "chained symlink",
+			entries: func(string) []entry {
+				return []entry{
+					{name: "go/p/q/s", typeflag: tar.TypeSymlink, linkname: "../.."},
+					{name: "go/t", typeflag: tar.TypeSymlink, linkname: "p/q/s/.."},
+					{name: "go/t/escaped.txt", typeflag: tar.TypeReg, body: "pwned"},
+				}
+			},
+			escaped: "escaped.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outside := t.TempDir()
+			dst := filepath.Join(outside, "dst")
+			victim := filepath.Join(outside, "victim.txt")
+			if err := os.WriteFile(victim, []byte("original"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := untar(buildTar(t, tt.entries(outside)), dst); err == nil {
+				t.Fatal("untar() succeeded, want error")
+			}
+
+			content, err := os.ReadFile(victim)
+			if err != nil || string(content) != "original" {
+				t.Errorf("victim.txt = %q (%v), want unchanged", content, err)
+			}
+			if tt.escaped != "victim.txt" {
+				if _, err := os.Stat(filepath.Join(outside, tt.escaped)); !os.IsNotExist(err) {
+					t.Errorf("%s written outside dst", tt.escaped)
+				}
+			}
+		})
+	}
+}
+
+func TestUntarReplacesExistingLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on windows")
+	}
+
+	dst := t.TempDir()
+	err := untar(buildTar(t, []entry{
+		{name: "go/", typeflag: tar.TypeDir},
+		{name: "go/b", typeflag: tar.TypeReg, body: "original"},
+		{name: "go/hard", typeflag: tar.TypeLink, linkname: "go/b"},
+		{name: "go/hard", typeflag: tar.TypeReg, body: "hard"},
+		{name: "go/soft", typeflag: tar.TypeSymlink, linkname: "b"},
+		{name: "go/soft", typeflag: tar.TypeReg, body: "soft"},
+		{name: "go/bin/go", typeflag: tar.TypeSymlink, linkname: "../b"},
+	}), dst)
+	if err != nil {
+		t.Fatalf("untar() error: %v", err)
+	}
+
+	for name, want := range map[string]string{"b": "original", "hard": "hard", "soft": "soft", "bin/go": "original"} {
+		content, err := os.ReadFile(filepath.Join(dst, "go", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != want {
+			t.Errorf("go/%s = %q, want %q", name, content, want)
+		}
+	}
+	if fi, err := os.Lstat(filepath.Join(dst, "go", "soft")); err != nil || !fi.Mode().IsRegular() {
+		t.Errorf("go/soft is not a regular file: %v", err)
+	}
+}
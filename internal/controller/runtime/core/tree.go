@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// HashTree 计算目录下所有普通文件的sha256(键为以/分隔的相对路径)
+func HashTree(root string) (map[string]string, error) {
+	tree := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		sum, err := HashFile(path)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	return tree, err
+}
+
+// WriteTree 写入文件哈希列表(兼容 sha256sum 输出格式)
+func WriteTree(fp string, tree map[string]string) error {
+	paths := make([]string, 0, len(tree))
+	for path := range tree {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var sb strings.Builder
+	for _, path := range paths {
+		sb.WriteString(tree[path] + "  " + path + "\n")
+	}
+	return os.WriteFile(fp, []byte(sb.String()), 0644)
+}
+
+// ReadTree 读取文件哈希列表
+func ReadTree(fp string) (map[string]string, error) {
+	// #nosec G304
+	fObj, err := os.Open(fp)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fObj.Close()
+	}()
+
+	tree := make(map[string]string)
+	scanner := bufio.NewScanner(fObj)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		sum, path, ok := strings.Cut(line, "  ")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q in %s", line, fp)
+		}
+		tree[path] = sum
+	}
+	return tree, scanner.Err()
+}
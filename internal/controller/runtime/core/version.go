@@ -1,91 +1,50 @@
 package core
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"net/http"
-	"net/url"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
-	"runtime"
-	"strconv"
-	"time"
+	"strings"
 
 	"github.com/c4milo/unpackit"
-	"github.com/schollz/progressbar/v3"
-
-	"github.com/justwhenjing/gvm/internal/util/httpcli"
 )
 
-// Download 下载版本
-func (c *Core) Download(repo string, version string, destFolder string) (string, error) {
-	// 1) 创建父目录
-	tarName := fullName(version)
-	if err := os.MkdirAll(destFolder, 0755); err != nil {
-		return "", err
+// Verify 校验文件的sha256(checksum为空时跳过)
+func (c *Core) Verify(src string, checksum string) error {
+	if checksum == "" {
+		return nil
 	}
 
-	// 2) 下载版本
-	downloadURL, _ := url.JoinPath(repo, tarName)
-	dest := filepath.Join(destFolder, tarName)
-	c.logger.Debug("download", "src", downloadURL, "dest", dest)
-
-	client := httpcli.NewClient(
-		httpcli.WithDebug(c.o.verbose),
-	)
-
-	// 3) 配置进度条
-	done := make(chan bool)
-
-	headResp, err := client.Head(downloadURL)
+	actual, err := HashFile(src)
 	if err != nil {
-		return "", err
+		return err
 	}
-	var total int64
-	contentLength := headResp.Header().Get("Content-Length")
-	if contentLength != "" {
-		total, _ = strconv.ParseInt(contentLength, 10, 64)
+	if !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, actual %s", filepath.Base(src), checksum, actual)
 	}
+	return nil
+}
 
-	bar := progressbar.DefaultBytes(total, "Downloading")
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				info, err := os.Stat(dest)
-				if err == nil {
-					current := info.Size()
-					_ = bar.Set64(current)
-				}
-			case <-done:
-				// 设置最终进度为 100%
-				if total > 0 {
-					_ = bar.Set64(total)
-				}
-				return
-			}
-		}
-	}()
-
-	resp, err := client.GetWithOutput(downloadURL, dest)
+// HashFile 计算文件的sha256
+func HashFile(src string) (string, error) {
+	// #nosec G304
+	fObj, err := os.Open(src)
 	if err != nil {
-		close(done)
 		return "", err
 	}
+	defer func() {
+		_ = fObj.Close()
+	}()
 
-	if resp.StatusCode() != http.StatusOK {
-		close(done)
-		return "", fmt.Errorf("%s returned status code %d", downloadURL, resp.StatusCode())
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, fObj); err != nil {
+		return "", err
 	}
-
-	close(done)
-	// 等待完成
-	time.Sleep(300 * time.Millisecond)
-
-	c.logger.Info("download completed")
-	return dest, nil
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 // Extract 解压版本
@@ -101,12 +60,37 @@ func (c *Core) Extract(src string, dst string) error {
 	if err != nil {
 		return err
 	}
+	defer func() {
+		_ = fObj.Close()
+	}()
 
 	// 支持解压 tar.gz zip
 	return unpackit.Unpack(fObj, dst)
 }
 
-// fullName 完整的文件名
-func fullName(version string) string {
-	return fmt.Sprintf("go%s.%s-%s%s", version, runtime.GOOS, runtime.GOARCH, TarExt)
+// Validate 校验GOROOT可用(执行 go version,expect不为空时校验版本)
+func (c *Core) Validate(goroot string, expect string) error {
+	goBin := filepath.Join(goroot, "bin", "go"+FileExt)
+	if _, err := os.Stat(goBin); err != nil {
+		return fmt.Errorf("invalid go root %s: %w", goroot, err)
+	}
+
+	// #nosec G204
+	cmd := exec.Command(goBin, "version")
+	cmd.Env = append(os.Environ(), "GOROOT="+goroot, "GOTOOLCHAIN=local")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("run %s version failed: %w: %s", goBin, err, strings.TrimSpace(string(output)))
+	}
+	c.logger.Debug("validate go root", "goroot", goroot, "output", strings.TrimSpace(string(output)))
+
+	// 输出格式: go version go1.22.4 linux/amd64
+	if expect == "" {
+		return nil
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) < 3 || fields[2] != "go"+expect {
+		return fmt.Errorf("go root %s reports %q, expect go%s", goroot, strings.TrimSpace(string(output)), expect)
+	}
+	return nil
 }
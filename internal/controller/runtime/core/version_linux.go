@@ -4,6 +4,7 @@
 package core
 
 const (
-	FileExt = ""
-	TarExt  = ".tar.gz"
+	FileExt    = ""
+	TarExt     = ".tar.gz"
+	MakeScript = "make.bash"
 )
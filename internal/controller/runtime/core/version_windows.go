@@ -4,6 +4,7 @@
 package core
 
 const (
-	FileExt = ".exe"
-	TarExt  = ".zip"
+	FileExt    = ".exe"
+	TarExt     = ".zip"
+	MakeScript = "make.bat"
 )
@@ -0,0 +1,372 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/project"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// envVar 环境变量
+type envVar struct {
+	name  string
+	value string
+	unset bool // 是否取消设置
+}
+
+// Env 输出使用指定版本(未指定时为当前版本)所需的环境变量
+func (r *Runtime) Env(version string) error {
+	goroot, binDir := r.o.currentGoDir, r.o.currentBinDir
+	if version != "" {
+		resolved, err := r.ResolveInstalled(version)
+		if err != nil {
+			return err
+		}
+		goroot = filepath.Join(r.o.versionsDir, resolved, "go")
+		binDir = filepath.Join(goroot, "bin")
+	} else if !fileop.Exist(goroot) {
+		return fmt.Errorf("no version in use, run gvm use first or specify a version")
+	}
+
+	vars, err := r.envVars(goroot, binDir)
+	if err != nil {
+		return err
+	}
+
+	shell := r.o.shell
+	if shell == "" {
+		shell = detectShell()
+	}
+	return r.printEnv(shell, vars)
+}
+
+// envVars 生成环境变量(PATH中去除gvm根目录下的旧路径,避免重复)
+// goroot为空时不设置GOROOT
+func (r *Runtime) envVars(goroot string, binDir string) ([]envVar, error) {
+	binDir, err := filepath.Abs(binDir)
+	if err != nil {
+		return nil, err
+	}
+	root, err := filepath.Abs(r.o.rootDir)
+	if err != nil {
+		return nil, err
+	}
+	shimsDir, err := filepath.Abs(r.o.shimsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 保留垫片目录(会话版本优先于垫片)
+	paths := []string{binDir}
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if p == "" || p == binDir {
+			continue
+		}
+		if p != shimsDir && (p == root || strings.HasPrefix(p, root+string(filepath.Separator))) {
+			continue
+		}
+		paths = append(paths, p)
+	}
+
+	vars := []envVar{{name: "GVM_ROOT", value: root}}
+	if goroot != "" {
+		goroot, err = filepath.Abs(goroot)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, envVar{name: "GOROOT", value: goroot})
+	}
+	vars = append(vars, envVar{name: "PATH", value: strings.Join(paths, string(os.PathListSeparator))})
+	if r.o.goPath != "" {
+		vars = append(vars, envVar{name: "GOPATH", value: r.o.goPath})
+	}
+	if r.o.toolchain != "" {
+		vars = append(vars, envVar{name: "GOTOOLCHAIN", value: r.o.toolchain})
+	}
+	return vars, nil
+}
+
+// printEnv 按shell格式输出环境变量
+func (r *Runtime) printEnv(shell string, vars []envVar) error {
+	switch shell {
+	case config.ShellJSON:
+		// 取消设置的变量输出为null
+		m := make(map[string]*string, len(vars))
+		for _, v := range vars {
+			if v.unset {
+				m[v.name] = nil
+				continue
+			}
+			m[v.name] = &v.value
+		}
+		content, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(r.o.out, string(content))
+		return err
+	case config.ShellFish:
+		for _, v := range vars {
+			if v.unset {
+				if _, err := fmt.Fprintf(r.o.out, "set -e %s;\n", v.name); err != nil {
+					return err
+				}
+				continue
+			}
+			values := []string{v.value}
+			if v.name == "PATH" {
+				values = filepath.SplitList(v.value)
+			}
+			for i := range values {
+				values[i] = quoteFish(values[i])
+			}
+			if _, err := fmt.Fprintf(r.o.out, "set -gx %s %s;\n", v.name, strings.Join(values, " ")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		for _, v := range vars {
+			if v.unset {
+				if _, err := fmt.Fprintf(r.o.out, "unset %s\n", v.name); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintf(r.o.out, "export %s=%s\n", v.name, quotePosix(v.value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Init 输出shell初始化脚本(设置环境变量,并在切换目录时按项目版本文件自动切换版本)
+func (r *Runtime) Init(shell string) error {
+	if shell == "" {
+		shell = detectShell()
+	}
+	hook, ok := initHooks[shell]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q, expect one of %s/%s/%s/%s",
+			shell, config.ShellBash, config.ShellZsh, config.ShellFish, config.ShellPosix)
+	}
+
+	// 使用固定的当前版本软链接,切换版本后无需重新设置
+	goroot, binDir := r.o.currentGoDir, r.o.currentBinDir
+	if r.o.shims {
+		// 垫片按调用时的版本执行,不设置GOROOT,也不需要切换目录钩子
+		if !fileop.Exist(r.o.shimsDir) {
+			return fmt.Errorf("no shims found in %s, run gvm rehash first", r.o.shimsDir)
+		}
+		goroot, binDir, hook = "", r.o.shimsDir, ""
+	}
+	vars, err := r.envVars(goroot, binDir)
+	if err != nil {
+		return err
+	}
+	root := vars[0].value
+
+	if _, err := fmt.Fprintf(r.o.out, "# gvm shell integration, add `eval \"$(gvm init %s)\"` to your shell rc file\n", shell); err != nil {
+		return err
+	}
+	if err := r.printEnv(shell, vars); err != nil {
+		return err
+	}
+
+	gvm, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	quote := quotePosix
+	if shell == config.ShellFish {
+		quote = quoteFish
+	}
+
+	// gvm 函数用于执行 gvm shell 输出的环境变量
+	wrapper := initWrappers[config.ShellPosix]
+	if shell == config.ShellFish {
+		wrapper = initWrappers[config.ShellFish]
+	}
+	if _, err := fmt.Fprintf(r.o.out, wrapper, quote(gvm), shell); err != nil {
+		return err
+	}
+	if hook == "" {
+		return nil
+	}
+	_, err = fmt.Fprintf(r.o.out, hook, quote(gvm)+" --root "+quote(root), shell)
+	return err
+}
+
+// Shell 输出仅对当前shell会话生效的版本切换(未指定版本时显示生效版本及其来源)
+func (r *Runtime) Shell(version string) error {
+	shell := r.o.shell
+	if shell == "" {
+		shell = detectShell()
+	}
+
+	// 取消会话版本,恢复为全局版本(使用垫片时恢复为垫片)
+	if r.o.unset {
+		if version != "" {
+			return fmt.Errorf("version %s cannot be used with --unset", version)
+		}
+		goroot, binDir := r.o.currentGoDir, r.o.currentBinDir
+		if r.onPath(r.o.shimsDir) {
+			goroot, binDir = "", r.o.shimsDir
+		}
+		vars, err := r.envVars(goroot, binDir)
+		if err != nil {
+			return err
+		}
+		vars = append(vars, envVar{name: EnvVersion, unset: true})
+		if goroot == "" {
+			vars = append(vars, envVar{name: "GOROOT", unset: true})
+		}
+		return r.printEnv(shell, vars)
+	}
+
+	// 项目版本(切换目录钩子使用),仅修改当前会话且不设置会话版本,没有项目版本时恢复为全局版本
+	if r.o.project {
+		if version != "" {
+			return fmt.Errorf("version %s cannot be used with --project", version)
+		}
+		goroot, binDir := r.o.currentGoDir, r.o.currentBinDir
+		resolved, err := r.ProjectVersion()
+		switch {
+		case err == nil:
+			goroot = filepath.Join(r.o.versionsDir, resolved, "go")
+			binDir = filepath.Join(goroot, "bin")
+		case !errors.Is(err, project.ErrNotFound):
+			return err
+		}
+		vars, err := r.envVars(goroot, binDir)
+		if err != nil {
+			return err
+		}
+		return r.printEnv(shell, vars)
+	}
+
+	if version == "" {
+		effective, origin, err := r.EffectiveVersion()
+		if err != nil {
+			return err
+		}
+		r.logger.Info("effective version", "version", effective, "origin", origin)
+		return nil
+	}
+
+	resolved, err := r.ResolveInstalled(version)
+	if err != nil {
+		return err
+	}
+	goroot := filepath.Join(r.o.versionsDir, resolved, "go")
+	vars, err := r.envVars(goroot, filepath.Join(goroot, "bin"))
+	if err != nil {
+		return err
+	}
+	vars = append(vars, envVar{name: EnvVersion, value: resolved})
+	return r.printEnv(shell, vars)
+}
+
+// onPath 判断目录是否在PATH中
+func (r *Runtime) onPath(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		if p == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// initWrappers gvm 函数(gvm shell 指定版本时执行其输出,修改当前会话的环境变量)
+var initWrappers = map[string]string{
+	config.ShellPosix: `gvm() {
+  if [ "${1:-}" = "shell" ] && [ "$#" -gt 1 ]; then
+    shift
+    __gvm_exports="$(%[1]s shell --shell %[2]s "$@")" || return
+    eval "$__gvm_exports"
+  else
+    %[1]s "$@"
+  fi
+}
+`,
+	config.ShellFish: `function gvm
+    if test (count $argv) -gt 1; and test "$argv[1]" = shell
+        set -l exports (%[1]s shell --shell %[2]s $argv[2..-1]); or return
+        printf '%%s\n' $exports | source
+    else
+        %[1]s $argv
+    end
+end
+`,
+}
+
+// initHooks 切换目录时自动切换版本的钩子
+//
+// 仅执行 gvm shell --project 输出的环境变量修改当前会话,不修改全局版本也不等待锁;
+// 设置了会话版本时跳过,posix shell 不支持
+var initHooks = map[string]string{
+	config.ShellBash: `__gvm_hook() {
+  [ -n "${GVM_VERSION:-}" ] && return
+  if [ "${__GVM_PWD:-}" != "$PWD" ]; then
+    __GVM_PWD="$PWD"
+    if __gvm_exports="$(%[1]s --lock-timeout 0 shell --project --shell %[2]s 2>/dev/null)"; then
+      eval "$__gvm_exports"
+    fi
+  fi
+}
+case ";${PROMPT_COMMAND:-};" in
+  *";__gvm_hook;"*) ;;
+  *) PROMPT_COMMAND="__gvm_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}" ;;
+esac
+`,
+	config.ShellZsh: `__gvm_hook() {
+  [ -n "${GVM_VERSION:-}" ] && return
+  if __gvm_exports="$(%[1]s --lock-timeout 0 shell --project --shell %[2]s 2>/dev/null)"; then
+    eval "$__gvm_exports"
+  fi
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook chpwd __gvm_hook
+__gvm_hook
+`,
+	config.ShellFish: `function __gvm_hook --on-variable PWD
+    set -q GVM_VERSION; and return
+    set -l exports (%[1]s --lock-timeout 0 shell --project --shell %[2]s 2>/dev/null); or return 0
+    printf '%%s\n' $exports | source
+end
+__gvm_hook
+`,
+	config.ShellPosix: "",
+}
+
+// detectShell 根据 $SHELL 判断shell类型
+func detectShell() string {
+	switch name := filepath.Base(os.Getenv("SHELL")); name {
+	case config.ShellBash, config.ShellZsh, config.ShellFish:
+		return name
+	default:
+		return config.ShellPosix
+	}
+}
+
+// quotePosix 使用单引号转义(posix shell)
+func quotePosix(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish 使用单引号转义(fish)
+func quoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
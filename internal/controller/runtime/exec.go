@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Exec 使用指定版本执行命令(不切换当前版本),命令的退出状态码原样返回
+func (r *Runtime) Exec(spec string, command []string) error {
+	if len(command) == 0 {
+		return fmt.Errorf("command is required")
+	}
+
+	version, err := r.ResolveInstalled(spec)
+	if err != nil {
+		if !r.o.installMissing {
+			return fmt.Errorf("%w (use --install to install it)", err)
+		}
+		if version, err = r.installMissing(spec); err != nil {
+			return err
+		}
+	}
+
+	goroot := filepath.Join(r.o.versionsDir, version, "go")
+	env := toolEnv(goroot, "GOTOOLCHAIN=local")
+	bin, err := lookPath(command[0], env)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("exec with version", "version", version, "command", command)
+	return r.core.Exec(bin, command[1:], env)
+}
+
+// installMissing 安装缺失的版本(不切换当前版本)
+func (r *Runtime) installMissing(spec string) (string, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	version, err := r.installRemote(spec)
+	if err != nil {
+		return "", err
+	}
+	r.rehashIfEnabled()
+	return version, nil
+}
+
+// lookPath 在指定环境变量的PATH中查找命令
+func lookPath(name string, env []string) (string, error) {
+	if strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, '/') {
+		return name, nil
+	}
+
+	var path string
+	for _, kv := range env {
+		if key, value, _ := strings.Cut(kv, "="); strings.EqualFold(key, "PATH") {
+			path = value
+		}
+	}
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		for _, ext := range execExts() {
+			fp := filepath.Join(dir, name+ext)
+			if info, err := os.Stat(fp); err == nil && !info.IsDir() && isExecutable(info) {
+				return fp, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s: %w", name, exec.ErrNotFound)
+}
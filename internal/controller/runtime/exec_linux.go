@@ -0,0 +1,16 @@
+//go:build linux
+// +build linux
+
+package runtime
+
+import "os"
+
+// execExts 可执行文件扩展名
+func execExts() []string {
+	return []string{""}
+}
+
+// isExecutable 判断文件是否可执行
+func isExecutable(info os.FileInfo) bool {
+	return info.Mode()&0111 != 0
+}
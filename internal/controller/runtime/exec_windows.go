@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package runtime
+
+import (
+	"os"
+	"strings"
+)
+
+// execExts 可执行文件扩展名(参考 PATHEXT)
+func execExts() []string {
+	exts := []string{""}
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".COM;.EXE;.BAT;.CMD"
+	}
+	for _, ext := range strings.Split(pathExt, ";") {
+		if ext != "" {
+			exts = append(exts, strings.ToLower(ext))
+		}
+	}
+	return exts
+}
+
+// isExecutable 判断文件是否可执行(windows按扩展名判断)
+func isExecutable(info os.FileInfo) bool {
+	return true
+}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+)
+
+// historyMaxEntries 切换历史最多保留条数
+const historyMaxEntries = 1000
+
+// PreviousVersion gvm use 使用的上一个版本标记
+const PreviousVersion = "-"
+
+// HistoryEntry 版本切换记录
+type HistoryEntry struct {
+	Time time.Time `json:"time"` // 切换时间
+	From string    `json:"from"` // 切换前版本
+	To   string    `json:"to"`   // 切换后版本
+	Cwd  string    `json:"cwd"`  // 切换时的工作目录
+}
+
+// History 显示最近的版本切换记录
+func (r *Runtime) History() error {
+	entries, err := r.readHistory()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		r.logger.Info("no switch history")
+		return nil
+	}
+
+	if r.o.limit > 0 && len(entries) > r.o.limit {
+		entries = entries[len(entries)-r.o.limit:]
+	}
+	for _, entry := range entries {
+		r.logger.Info(entry.Time.Local().Format(time.DateTime),
+			"from", entry.From,
+			"to", entry.To,
+			"cwd", entry.Cwd,
+		)
+	}
+	return nil
+}
+
+// previousVersion 获取切换到当前版本之前使用的版本
+func (r *Runtime) previousVersion() (string, error) {
+	entries, err := r.readHistory()
+	if err != nil {
+		return "", err
+	}
+
+	cv := r.CurrentVersion()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.To == cv && entry.From != "" && entry.From != core.NoneVersion {
+			return entry.From, nil
+		}
+	}
+	return "", fmt.Errorf("no previous version in switch history")
+}
+
+// recordHistory 记录版本切换(记录失败不影响切换)
+func (r *Runtime) recordHistory(from string, to string) {
+	cwd, _ := os.Getwd()
+	entries, err := r.readHistory()
+	if err != nil {
+		r.logger.Debug("read history failed", "error", err)
+	}
+	entries = append(entries, HistoryEntry{Time: time.Now(), From: from, To: to, Cwd: cwd})
+	if len(entries) > historyMaxEntries {
+		entries = entries[len(entries)-historyMaxEntries:]
+	}
+
+	if err := r.writeHistory(entries); err != nil {
+		r.logger.Debug("write history failed", "error", err)
+	}
+}
+
+// readHistory 读取切换历史(每行一条JSON记录,忽略损坏的行)
+func (r *Runtime) readHistory() ([]HistoryEntry, error) {
+	// #nosec G304
+	fObj, err := os.Open(r.o.historyFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = fObj.Close()
+	}()
+
+	entries := make([]HistoryEntry, 0)
+	scanner := bufio.NewScanner(fObj)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			r.logger.Debug("skip invalid history line", "line", line, "error", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// writeHistory 写入切换历史(先写临时文件再重命名)
+func (r *Runtime) writeHistory(entries []HistoryEntry) error {
+	var sb strings.Builder
+	for _, entry := range entries {
+		content, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		sb.Write(content)
+		sb.WriteByte('\n')
+	}
+
+	tmp := r.o.historyFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(sb.String()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.o.historyFile)
+}
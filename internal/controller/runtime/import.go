@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// installLocal 从本地压缩包或已解压的GOROOT安装(版本号取自 go/VERSION 文件)
+func (r *Runtime) installLocal(spec string) error {
+	// 创建暂存目录
+	stage, err := r.newStage("local")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(stage)
+	}()
+
+	staged := filepath.Join(stage, "root")
+	var manifest *Manifest
+	if r.o.fromFile != "" {
+		r.logger.Info("installing from file", "file", r.o.fromFile)
+		if r.o.checksum == "" {
+			r.logger.Warn("no checksum given, the archive will NOT be verified", "file", r.o.fromFile)
+		}
+		if err := r.core.Verify(r.o.fromFile, r.o.checksum); err != nil {
+			return err
+		}
+		if err := r.core.Extract(r.o.fromFile, staged); err != nil {
+			return err
+		}
+		manifest = r.newManifest("", MethodFile, r.o.fromFile).withFile(r.o.fromFile)
+	} else {
+		r.logger.Info("installing from dir", "dir", r.o.fromDir)
+		if _, err := os.Stat(filepath.Join(r.o.fromDir, "VERSION")); err != nil {
+			return fmt.Errorf("invalid go root %s: %w", r.o.fromDir, err)
+		}
+		if err := fileop.CopyDir(r.o.fromDir, filepath.Join(staged, "go")); err != nil {
+			return err
+		}
+		manifest = r.newManifest("", MethodDir, r.o.fromDir)
+	}
+
+	// 从 go/VERSION 推断版本(指定版本时需一致)
+	v, err := readVersionFile(filepath.Join(staged, "go", "VERSION"))
+	if err != nil {
+		return err
+	}
+	if spec != "" && spec != v {
+		return fmt.Errorf("version mismatch: expect %s, archive contains %s", spec, v)
+	}
+	if r.ExistVersion(v) {
+		r.logger.Info("version already exists", "version", v)
+		return r.use(v)
+	}
+
+	manifest.Version = v
+	if err := writeManifest(staged, manifest); err != nil {
+		return err
+	}
+	if err := r.commitStage(staged, v, v); err != nil {
+		return err
+	}
+	r.logger.Info("installed", "version", v)
+
+	return r.use(v)
+}
+
+// readVersionFile 读取 VERSION 文件(首行形如 go1.22.4)
+func readVersionFile(fp string) (string, error) {
+	// #nosec G304
+	fObj, err := os.Open(fp)
+	if err != nil {
+		return "", fmt.Errorf("read go version failed: %w", err)
+	}
+	defer func() {
+		_ = fObj.Close()
+	}()
+
+	scanner := bufio.NewScanner(fObj)
+	if !scanner.Scan() {
+		return "", fmt.Errorf("empty version file %s", fp)
+	}
+	line := strings.TrimSpace(scanner.Text())
+	v, err := version.Parse(line)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q in %s: %w", line, fp, err)
+	}
+	if core.NotSupportedVersion(v.String()) {
+		return "", fmt.Errorf("version %s is not supported", v)
+	}
+	return v.String(), nil
+}
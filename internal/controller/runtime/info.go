@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// Info 显示已安装版本的安装清单、磁盘占用及是否为当前版本
+func (r *Runtime) Info(version string) error {
+	resolved, err := r.ResolveInstalled(version)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(r.o.versionsDir, resolved)
+	goroot, err := filepath.EvalSymlinks(filepath.Join(dir, "go"))
+	if err != nil {
+		return err
+	}
+	size, err := fileop.DirSize(goroot)
+	if err != nil {
+		r.logger.Debug("calculate size failed", "dir", goroot, "error", err)
+	}
+	r.logger.Info(resolved,
+		"current", r.CurrentVersion() == resolved,
+		"goroot", goroot,
+		"disk_usage", fileop.HumanSize(size),
+	)
+
+	m, err := readManifest(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.logger.Info("no install manifest", "version", resolved)
+			return nil
+		}
+		return err
+	}
+	r.logger.Info("manifest",
+		"method", m.Method,
+		"source", m.Source,
+		"installed", m.Installed.Local().Format(time.DateTime),
+		"gvm_version", m.GVMVersion,
+		"platform", m.OS+"/"+m.Arch,
+	)
+	if m.SHA256 != "" {
+		r.logger.Info("archive", "sha256", m.SHA256, "size", fileop.HumanSize(m.Size))
+	}
+	if m.Ref != "" {
+		r.logger.Info("source", "ref", m.Ref)
+	}
+	for i, patch := range m.Patches {
+		r.logger.Info("patch", "order", i+1, "file", patch.File, "sha256", patch.SHA256)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Link 将外部GOROOT注册为指定名称的版本(版本目录中仅保存软链接)
+func (r *Runtime) Link(name string, goroot string) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.link(name, goroot); err != nil {
+		return err
+	}
+	r.rehashIfEnabled()
+	return nil
+}
+
+func (r *Runtime) link(name string, goroot string) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	dir := filepath.Join(r.o.versionsDir, name)
+	if _, err := os.Lstat(dir); err == nil {
+		return fmt.Errorf("version %s already exists", name)
+	}
+
+	goroot, err := filepath.Abs(goroot)
+	if err != nil {
+		return err
+	}
+	// 校验GOROOT可用(不校验版本号,允许打过补丁的工具链)
+	if err := r.core.Validate(goroot, ""); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.Symlink(goroot, filepath.Join(dir, "go")); err != nil {
+		_ = os.RemoveAll(dir)
+		return err
+	}
+	if err := writeManifest(dir, r.newManifest(name, MethodLink, goroot)); err != nil {
+		r.logger.Debug("write manifest failed", "version", name, "error", err)
+	}
+	r.logger.Info("linked", "version", name, "goroot", goroot)
+	return nil
+}
+
+// LinkedVersion 获取链接版本指向的GOROOT(非链接版本返回false)
+func (r *Runtime) LinkedVersion(version string) (string, bool) {
+	target, err := os.Readlink(filepath.Join(r.o.versionsDir, version, "go"))
+	if err != nil {
+		return "", false
+	}
+	return target, true
+}
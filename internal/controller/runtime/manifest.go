@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"time"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+)
+
+// manifestFile 安装清单文件名(位于版本目录下)
+const manifestFile = ".gvm-manifest.json"
+
+// 安装方式
+const (
+	MethodDownload = "download" // 从版本源下载
+	MethodFile     = "file"     // 从本地压缩包安装
+	MethodDir      = "dir"      // 从已解压的GOROOT复制
+	MethodSource   = "source"   // 从源码构建
+	MethodLink     = "link"     // 链接外部GOROOT
+)
+
+// Manifest 安装清单
+type Manifest struct {
+	Version    string    `json:"version"`           // 版本名称
+	Method     string    `json:"method"`            // 安装方式
+	Source     string    `json:"source,omitempty"`  // 来源(下载地址、本地路径或源码仓库)
+	Ref        string    `json:"ref,omitempty"`     // 源码检出的引用
+	SHA256     string    `json:"sha256,omitempty"`  // 压缩包sha256
+	Size       int64     `json:"size,omitempty"`    // 压缩包大小
+	Installed  time.Time `json:"installed"`         // 安装时间
+	GVMVersion string    `json:"gvm_version"`       // 安装使用的gvm版本
+	OS         string    `json:"os"`                // 操作系统
+	Arch       string    `json:"arch"`              // 架构
+	Patches    []Patch   `json:"patches,omitempty"` // 按顺序应用的补丁
+}
+
+// Patch 补丁信息
+type Patch struct {
+	File   string `json:"file"`   // 补丁文件名
+	SHA256 string `json:"sha256"` // 补丁sha256
+}
+
+// newManifest 创建安装清单
+func (r *Runtime) newManifest(version string, method string, source string) *Manifest {
+	return &Manifest{
+		Version:    version,
+		Method:     method,
+		Source:     source,
+		Installed:  time.Now(),
+		GVMVersion: r.o.gvmVersion,
+		OS:         goruntime.GOOS,
+		Arch:       goruntime.GOARCH,
+	}
+}
+
+// withFile 记录本地压缩包的校验和及大小
+func (m *Manifest) withFile(fp string) *Manifest {
+	if sum, err := core.HashFile(fp); err == nil {
+		m.SHA256 = sum
+	}
+	if info, err := os.Stat(fp); err == nil {
+		m.Size = info.Size()
+	}
+	return m
+}
+
+// writeManifest 写入安装清单
+func writeManifest(dir string, m *Manifest) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFile), append(content, '\n'), 0644)
+}
+
+// readManifest 读取安装清单
+func readManifest(dir string) (*Manifest, error) {
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -1,15 +1,49 @@
 package runtime
 
+import (
+	"io"
+	"time"
+)
+
 type Option struct {
-	currentDir    string // 当前版本目录
-	currentBinDir string // 当前版本二进制目录
-	currentGoDir  string // 当前版本go目录
-	versionsDir   string // 版本目录
-	downloadsDir  string // 下载目录
-	repoURL       string // 版本仓库URL
-	tagURL        string // 版本标签URL
-	verbose       bool   // 是否显示详细信息
-	remote        bool   // 是否显示远程版本信息
+	rootDir        string        // 根目录
+	currentDir     string        // 当前版本目录
+	currentBinDir  string        // 当前版本二进制目录
+	currentGoDir   string        // 当前版本go目录
+	shimsDir       string        // 垫片目录
+	versionsDir    string        // 版本目录
+	stagingDir     string        // 暂存目录
+	downloadsDir   string        // 下载目录
+	cacheFile      string        // 版本列表缓存文件
+	lockFile       string        // 根目录锁文件
+	historyFile    string        // 版本切换历史文件
+	lockTimeout    time.Duration // 等待锁超时时间
+	verbose        bool          // 是否显示详细信息
+	remote         bool          // 是否显示远程版本信息
+	force          bool          // 是否强制执行
+	dryRun         bool          // 是否仅演示不执行
+	skipChecksum   bool          // 是否跳过校验和检查
+	stream         bool          // 是否边下载边解压
+	fromFile       string        // 从本地压缩包安装
+	fromDir        string        // 从已解压的GOROOT安装
+	checksum       string        // 本地压缩包的sha256
+	sourceRepo     string        // 源码仓库
+	ref            string        // 源码检出的引用
+	name           string        // 源码构建安装的版本名称
+	patches        []string      // 构建前应用的补丁文件
+	bootstrap      string        // 构建使用的已安装版本
+	gvmVersion     string        // gvm版本(记录到安装清单)
+	all            bool          // 是否作用于所有版本
+	repair         bool          // 是否修复校验失败的版本
+	shell          string        // 输出环境变量的shell格式
+	goPath         string        // 输出的GOPATH
+	toolchain      string        // 输出的GOTOOLCHAIN
+	shims          bool          // 是否使用垫片模式
+	unset          bool          // 是否取消会话版本
+	project        bool          // 是否输出项目版本的环境变量
+	limit          int           // 显示的记录条数
+	installMissing bool          // 执行命令时是否安装缺失的版本
+	out            io.Writer     // 脚本输出(供shell执行的内容不经过日志)
 }
 
 func (o *Option) Apply(opts []OptionFunc) {
@@ -20,3 +54,10 @@ func (o *Option) Apply(opts []OptionFunc) {
 
 // 选项(后续可以使用)
 type OptionFunc func(o *Option)
+
+// WithOutput 设置脚本输出
+func WithOutput(w io.Writer) OptionFunc {
+	return func(o *Option) {
+		o.out = w
+	}
+}
@@ -0,0 +1,185 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
+)
+
+// 版本别名
+const (
+	AliasLatest    = "latest"    // 最新稳定版本
+	AliasStable    = "stable"    // 最新稳定版本
+	AliasOldStable = "oldstable" // 上一个次版本的最新稳定版本
+)
+
+// Candidate 候选版本
+type Candidate struct {
+	Version string // 版本号(不带go前缀)
+	Stable  bool   // 是否稳定版本
+}
+
+// IsExact 是否为精确版本(如 1.22.4/1.23rc1),无需候选版本即可解析
+func IsExact(spec string) bool {
+	v, err := version.Parse(spec)
+	if err != nil {
+		return false
+	}
+	return v.IsPrerelease() || strings.Count(spec, ".") == 2
+}
+
+// Line 版本所属的版本线(如 1.22.4 => 1.22)
+func Line(spec string) string {
+	v, err := version.Parse(spec)
+	if err != nil {
+		return spec
+	}
+	return v.Lang()
+}
+
+// Resolve 将版本约束或别名解析为候选版本中的具体版本
+//
+// 支持: latest/stable/oldstable, 精确版本(1.22.4), 版本线(1.22/1.22.x),
+// 预发布(1.23rc/1.23beta), 约束(~1.21, ^1.21, >=1.21 <1.23, >=1.21,<1.23)
+func Resolve(spec string, candidates []Candidate) (string, error) {
+	spec = strings.TrimSpace(strings.TrimPrefix(spec, "go"))
+	if spec == "" {
+		spec = AliasLatest
+	}
+
+	// 1) 别名
+	switch spec {
+	case AliasLatest, AliasStable:
+		return best(candidates, func(c Candidate, _ *version.Version) bool { return c.Stable })
+	case AliasOldStable:
+		latest, err := best(candidates, func(c Candidate, _ *version.Version) bool { return c.Stable })
+		if err != nil {
+			return "", err
+		}
+		lv, _ := version.Parse(latest)
+		return best(candidates, func(c Candidate, v *version.Version) bool {
+			return c.Stable && v.Major == lv.Major && v.Minor < lv.Minor
+		})
+	}
+
+	// 2) 预发布(1.23rc/1.23beta)
+	for _, kind := range []version.Kind{version.KindRC, version.KindBeta} {
+		line, ok := strings.CutSuffix(spec, kind.String())
+		if !ok || strings.Count(line, ".") != 1 {
+			continue
+		}
+		lv, err := version.Parse(line)
+		if err != nil {
+			continue
+		}
+		return best(candidates, func(_ Candidate, v *version.Version) bool {
+			return v.Lang() == lv.Lang() && v.Kind == kind
+		})
+	}
+
+	// 3) 版本线(1.22/1.22.x),两段版本号(如 1.20)同样按版本线解析
+	line := strings.TrimSuffix(strings.TrimSuffix(spec, ".x"), ".*")
+	if lv, err := version.Parse(line); err == nil && !lv.IsPrerelease() && strings.Count(line, ".") == 1 {
+		return best(candidates, func(c Candidate, v *version.Version) bool {
+			return c.Stable && v.Lang() == lv.Lang()
+		})
+	}
+
+	// 4) 精确匹配
+	for _, c := range candidates {
+		if c.Version == spec {
+			return c.Version, nil
+		}
+	}
+
+	// 5) 约束
+	match, err := parseConstraint(spec)
+	if err != nil {
+		return "", err
+	}
+	return best(candidates, func(c Candidate, v *version.Version) bool {
+		return c.Stable && match(v)
+	})
+}
+
+// best 返回满足条件的最高版本
+func best(candidates []Candidate, accept func(c Candidate, v *version.Version) bool) (string, error) {
+	var (
+		result string
+		top    *version.Version
+	)
+	for _, c := range candidates {
+		v, err := version.Parse(c.Version)
+		if err != nil || !accept(c, v) {
+			continue
+		}
+		if top == nil || v.Compare(top) > 0 {
+			result, top = c.Version, v
+		}
+	}
+
+	if top == nil {
+		return "", fmt.Errorf("no matching version found")
+	}
+	return result, nil
+}
+
+// parseConstraint 解析版本约束(多个条件以空格或逗号分隔,需同时满足)
+func parseConstraint(spec string) (func(v *version.Version) bool, error) {
+	clauses := strings.FieldsFunc(spec, func(ch rune) bool {
+		return ch == ',' || ch == ' '
+	})
+
+	matchers := make([]func(v *version.Version) bool, 0, len(clauses))
+	for i := 0; i < len(clauses); i++ {
+		clause := clauses[i]
+		// 兼容 ">= 1.21" 写法
+		if strings.Trim(clause, "<>=~^") == "" && i+1 < len(clauses) {
+			i++
+			clause += clauses[i]
+		}
+
+		rest := strings.TrimLeft(clause, "<>=~^")
+		op := strings.TrimSuffix(clause, rest)
+		target, err := version.Parse(strings.TrimSuffix(rest, ".x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q", spec)
+		}
+
+		var m func(v *version.Version) bool
+		switch op {
+		case ">=":
+			m = func(v *version.Version) bool { return v.Compare(target) >= 0 }
+		case ">":
+			m = func(v *version.Version) bool { return v.Compare(target) > 0 }
+		case "<=":
+			m = func(v *version.Version) bool { return v.Compare(target) <= 0 }
+		case "<":
+			m = func(v *version.Version) bool { return v.Compare(target) < 0 }
+		case "=", "==":
+			m = func(v *version.Version) bool { return v.Compare(target) == 0 }
+		case "~":
+			// ~1.21 => >=1.21.0 <1.22.0
+			m = func(v *version.Version) bool { return v.Compare(target) >= 0 && v.Lang() == target.Lang() }
+		case "^":
+			// ^1.21 => >=1.21.0 <2.0.0
+			m = func(v *version.Version) bool { return v.Compare(target) >= 0 && v.Major == target.Major }
+		default:
+			return nil, fmt.Errorf("invalid version constraint %q", spec)
+		}
+		matchers = append(matchers, m)
+	}
+
+	if len(matchers) == 0 {
+		return nil, fmt.Errorf("invalid version constraint %q", spec)
+	}
+	return func(v *version.Version) bool {
+		for _, m := range matchers {
+			if !m(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
@@ -0,0 +1,131 @@
+package resolver
+
+import "testing"
+
+var candidates = []Candidate{
+	{Version: "1.20", Stable: true},
+	{Version: "1.21.0", Stable: true},
+	{Version: "1.21.5", Stable: true},
+	{Version: "1.22.0", Stable: true},
+	{Version: "1.22.4", Stable: true},
+	{Version: "1.23beta1"},
+	{Version: "1.23rc1"},
+	{Version: "1.23rc2"},
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		// 别名
+		{spec: "", want: "1.22.4"},
+		{spec: "latest", want: "1.22.4"},
+		{spec: "stable", want: "1.22.4"},
+		{spec: "oldstable", want: "1.21.5"},
+		// 精确版本
+		{spec: "1.22.0", want: "1.22.0"},
+		{spec: "go1.21.5", want: "1.21.5"},
+		{spec: "1.23rc1", want: "1.23rc1"},
+		// 版本线
+		{spec: "1.22", want: "1.22.4"},
+		{spec: "go1.22", want: "1.22.4"},
+		{spec: "1.22.x", want: "1.22.4"},
+		{spec: "1.21.*", want: "1.21.5"},
+		{spec: "1.20", want: "1.20"},
+		// 预发布
+		{spec: "1.23rc", want: "1.23rc2"},
+		{spec: "1.23beta", want: "1.23beta1"},
+		// 约束
+		{spec: "~1.21", want: "1.21.5"},
+		{spec: "~1.21.0", want: "1.21.5"},
+		{spec: "^1.21", want: "1.22.4"},
+		{spec: ">=1.21 <1.22", want: "1.21.5"},
+		{spec: ">=1.21,<1.22", want: "1.21.5"},
+		{spec: ">= 1.21 < 1.22", want: "1.21.5"},
+		{spec: "<1.21", want: "1.20"},
+		{spec: ">1.22.0", want: "1.22.4"},
+		{spec: "=1.22.0", want: "1.22.0"},
+		// 无匹配
+		{spec: "1.19", wantErr: true},
+		{spec: "1.24rc", wantErr: true},
+		{spec: "^2.0", wantErr: true},
+		{spec: ">=1.23", wantErr: true},
+		// 非法约束
+		{spec: "abc", wantErr: true},
+		{spec: "~", wantErr: true},
+		{spec: "!1.22", wantErr: true},
+		{spec: ">=1.21 <abc", wantErr: true},
+		{spec: "1.22.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := Resolve(tt.spec, candidates)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Resolve(%q) = %q, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveNoCandidates(t *testing.T) {
+	for _, spec := range []string{"latest", "oldstable", "1.22", "~1.21"} {
+		if got, err := Resolve(spec, nil); err == nil {
+			t.Errorf("Resolve(%q, nil) = %q, want error", spec, got)
+		}
+	}
+}
+
+func TestIsExact(t *testing.T) {
+	tests := []struct {
+		spec string
+		want bool
+	}{
+		{spec: "1.22.4", want: true},
+		{spec: "go1.22.4", want: true},
+		{spec: "1.23rc1", want: true},
+		{spec: "1.22", want: false},
+		{spec: "1.22.x", want: false},
+		{spec: "latest", want: false},
+		{spec: "~1.21", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := IsExact(tt.spec); got != tt.want {
+				t.Errorf("IsExact(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{spec: "1.22.4", want: "1.22"},
+		{spec: "go1.21.0", want: "1.21"},
+		{spec: "1.23rc1", want: "1.23"},
+		{spec: "custom", want: "custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := Line(tt.spec); got != tt.want {
+				t.Errorf("Line(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
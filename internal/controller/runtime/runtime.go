@@ -1,67 +1,150 @@
 package runtime
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/controller/project"
 	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/resolver"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/source"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/store"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
 	"github.com/justwhenjing/gvm/internal/util/log"
 )
 
 type Runtime struct {
-	logger log.ILog   // 日志接口
-	core   core.ICore // 核心接口
+	logger log.ILog       // 日志接口
+	core   core.ICore     // 核心接口
+	source source.ISource // 版本源接口
+	store  store.IStore   // 压缩包存储接口
 
 	o *Option // 选项
 }
 
 func NewRuntime(logger log.ILog, c *config.Config, opts ...OptionFunc) IRuntime {
 	o := &Option{
-		currentDir:    filepath.Join(c.RootDir, "current"),
-		currentBinDir: filepath.Join(c.RootDir, "current", "bin"),
-		currentGoDir:  filepath.Join(c.RootDir, "current", "go"),
-		versionsDir:   filepath.Join(c.RootDir, "versions"),
-		downloadsDir:  filepath.Join(c.RootDir, "downloads"),
-		repoURL:       c.Repo,
-		tagURL:        c.TagURL,
-		verbose:       c.Verbose,
-		remote:        c.Remote,
+		rootDir:        c.RootDir,
+		currentDir:     filepath.Join(c.RootDir, "current"),
+		currentBinDir:  filepath.Join(c.RootDir, "current", "bin"),
+		currentGoDir:   filepath.Join(c.RootDir, "current", "go"),
+		shimsDir:       filepath.Join(c.RootDir, "shims"),
+		versionsDir:    filepath.Join(c.RootDir, "versions"),
+		stagingDir:     filepath.Join(c.RootDir, "staging"),
+		downloadsDir:   filepath.Join(c.RootDir, "downloads"),
+		cacheFile:      filepath.Join(c.RootDir, "cache.json"),
+		lockFile:       filepath.Join(c.RootDir, "gvm.lock"),
+		historyFile:    filepath.Join(c.RootDir, "history.jsonl"),
+		lockTimeout:    c.LockTimeout,
+		verbose:        c.Verbose,
+		remote:         c.Remote,
+		force:          c.Force,
+		dryRun:         c.DryRun,
+		skipChecksum:   c.InsecureSkipChecksum,
+		stream:         c.Stream,
+		fromFile:       c.FromFile,
+		fromDir:        c.FromDir,
+		checksum:       c.Checksum,
+		sourceRepo:     c.SourceRepo,
+		ref:            c.Ref,
+		name:           c.Name,
+		patches:        c.Patches,
+		bootstrap:      c.Bootstrap,
+		gvmVersion:     c.GVMVersion,
+		all:            c.All,
+		repair:         c.Repair,
+		shell:          c.Shell,
+		goPath:         c.GoPath,
+		toolchain:      c.Toolchain,
+		shims:          c.Shims,
+		unset:          c.Unset,
+		project:        c.Project,
+		limit:          c.Limit,
+		installMissing: c.InstallMissing,
+		out:            os.Stdout,
 	}
 	o.Apply(opts)
 
 	return &Runtime{
 		logger: logger,
 		core:   core.NewCore(logger.With("runtime", "core"), c),
+		source: source.New(logger.With("runtime", "source"), c),
+		store:  store.NewStore(logger.With("runtime", "store"), c),
 		o:      o,
 	}
 }
 
-// Use 使用指定版本
+// Use 使用指定版本(未指定时使用项目声明的版本)
 func (r *Runtime) Use(version string) error {
-	if r.CurrentVersion() == version {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// 仅记录用户显式切换的版本(安装、修复等内部切换不记录)
+	from := r.CurrentVersion()
+	if err := r.use(version); err != nil {
+		return err
+	}
+	if to := r.CurrentVersion(); to != from {
+		r.recordHistory(from, to)
+	}
+	return nil
+}
+
+func (r *Runtime) use(version string) error {
+	if version == PreviousVersion {
+		previous, err := r.previousVersion()
+		if err != nil {
+			return err
+		}
+		version = previous
+	} else if version == "" {
+		projectVersion, err := r.ProjectVersion()
+		if err != nil {
+			return err
+		}
+		version = projectVersion
+	} else if !r.ExistVersion(version) {
+		resolved, err := r.ResolveInstalled(version)
+		if err != nil {
+			return err
+		}
+		version = resolved
+	}
+
+	cv := r.CurrentVersion()
+	if cv == version {
 		r.logger.Info("already using", "version", version)
 		return nil
 	}
 
-	// 设置go目录软链接
-	_ = os.RemoveAll(r.o.currentGoDir)
+	// 校验目标版本已安装
 	goDir := filepath.Join(r.o.versionsDir, version, "go")
-	if err := os.MkdirAll(filepath.Dir(r.o.currentGoDir), 0755); err != nil {
-		return err
+	binDir := filepath.Join(goDir, "bin")
+	if _, err := os.Stat(binDir); err != nil {
+		return fmt.Errorf("version %s is not installed", version)
 	}
-	if err := os.Symlink(goDir, r.o.currentGoDir); err != nil {
+	if err := os.MkdirAll(r.o.currentDir, 0755); err != nil {
 		return err
 	}
 
-	// 设置bin目录软链接
-	_ = os.RemoveAll(r.o.currentBinDir)
-	binDir := filepath.Join(r.o.versionsDir, version, "go", "bin")
-	if err := os.MkdirAll(filepath.Dir(r.o.currentBinDir), 0755); err != nil {
+	// 原子替换go目录软链接(失败时保持原状)
+	prevGoDir, _ := os.Readlink(r.o.currentGoDir)
+	if err := fileop.Symlink(goDir, r.o.currentGoDir); err != nil {
 		return err
 	}
-	if err := os.Symlink(binDir, r.o.currentBinDir); err != nil {
+
+	// 原子替换bin目录软链接(失败时回滚go目录软链接,保证两者一致)
+	if err := fileop.Symlink(binDir, r.o.currentBinDir); err != nil {
+		if prevGoDir != "" {
+			_ = fileop.Symlink(prevGoDir, r.o.currentGoDir)
+		}
 		return err
 	}
 
@@ -69,54 +152,419 @@ func (r *Runtime) Use(version string) error {
 	return nil
 }
 
-// Install 安装指定版本
-func (r *Runtime) Install(version string) error {
+// Local 设置当前目录的项目版本并切换(未指定时显示项目版本)
+func (r *Runtime) Local(version string) error {
 	if version == "" {
-		// 不指定版本则获取最新的稳定版本
-		latestVersion, err := r.LatestRemoteVersion()
-		if err != nil {
-			return err
-		}
-		version = latestVersion
+		_, err := r.ProjectVersion()
+		return err
+	}
+
+	resolved, err := r.ResolveInstalled(version)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	fp, err := project.Write(cwd, version)
+	if err != nil {
+		return err
+	}
+	r.logger.Info("set project version", "version", version, "file", fp)
+
+	return r.Use(resolved)
+}
+
+// Install 安装指定版本
+func (r *Runtime) Install(spec string) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.install(spec); err != nil {
+		return err
+	}
+	r.rehashIfEnabled()
+	return nil
+}
+
+func (r *Runtime) install(spec string) error {
+	// 从源码构建安装(可应用补丁)
+	if r.o.sourceRepo != "" || len(r.o.patches) > 0 {
+		return r.installSource(spec)
+	}
+	// 从本地压缩包或目录安装(不访问网络)
+	if r.o.fromFile != "" || r.o.fromDir != "" {
+		return r.installLocal(spec)
+	}
+
+	version, err := r.installRemote(spec)
+	if err != nil {
+		return err
+	}
+
+	// 安装版本
+	return r.use(version)
+}
+
+// installRemote 从版本源下载安装版本(不切换当前版本),返回安装的版本
+func (r *Runtime) installRemote(spec string) (string, error) {
+	// 不指定版本则获取最新的稳定版本
+	if spec == "" {
+		spec = resolver.AliasLatest
+	}
+	version, err := r.ResolveRemote(spec)
+	if err != nil {
+		return "", err
 	}
 	r.logger.Info("installing", "version", version)
 
 	// 查看版本是否已存在
 	if r.ExistVersion(version) {
 		r.logger.Info("version already exists", "version", version)
-		if r.CurrentVersion() != version {
-			return r.Use(version)
-		}
-		return nil
+		return version, nil
 	}
 
-	// 下载版本
+	// 解析压缩包及校验和
+	archive, err := r.resolveArchive(version)
+	if err != nil {
+		return "", err
+	}
+	checksum, err := r.checksum(archive)
+	if err != nil {
+		return "", err
+	}
+
+	// 创建暂存目录(解压在暂存目录中完成)
+	stage, err := r.newStage(version)
+	if err != nil {
+		return "", err
+	}
 	defer func() {
-		_ = os.RemoveAll(r.o.downloadsDir)
+		_ = os.RemoveAll(stage)
 	}()
-	tarName, err := r.core.Download(r.o.repoURL, version, r.o.downloadsDir)
+
+	// 下载并解压版本
+	staged := filepath.Join(stage, version)
+	if err := r.fetch(archive, checksum, staged); err != nil {
+		return "", err
+	}
+	manifest := r.newManifest(version, MethodDownload, archive.URL)
+	manifest.SHA256 = checksum
+	manifest.Size = archive.Size
+	if err := writeManifest(staged, manifest); err != nil {
+		return "", err
+	}
+	if err := r.commitStage(staged, version, version); err != nil {
+		return "", err
+	}
+	r.pruneArchives()
+
+	return version, nil
+}
+
+// lock 获取根目录锁(修改根目录的操作需持有该锁),返回释放函数
+func (r *Runtime) lock() (func(), error) {
+	l, err := fileop.NewLock(r.o.lockFile, r.o.lockTimeout, func(holder string) {
+		r.logger.Info("waiting for another gvm process", "pid", holder, "lock", r.o.lockFile, "timeout", r.o.lockTimeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		if err := l.Unlock(); err != nil {
+			r.logger.Debug("unlock failed", "lock", r.o.lockFile, "error", err)
+		}
+	}, nil
+}
+
+// resolveArchive 从版本源解析压缩包,版本源不可用时使用缓存的压缩包(离线安装)
+func (r *Runtime) resolveArchive(version string) (*source.Archive, error) {
+	archive, err := r.source.Resolve(version)
+	if err == nil {
+		return archive, nil
+	}
+
+	entry, ok := r.store.Find(source.ArchiveName(version))
+	if !ok {
+		return nil, err
+	}
+	r.logger.Warn("resolve from sources failed, using cached archive", "error", err, "archive", entry.Path())
+	return &source.Archive{
+		Version:  version,
+		Filename: entry.Name,
+		URL:      entry.Path(),
+		SHA256:   entry.SHA256,
+		Size:     entry.Size,
+		Local:    true,
+	}, nil
+}
+
+// fetch 获取压缩包并解压到暂存目录
+func (r *Runtime) fetch(archive *source.Archive, checksum string, staged string) error {
+	// 优先使用缓存的压缩包
+	if checksum != "" && !archive.Local {
+		if cached, ok := r.store.Get(checksum); ok {
+			err := r.core.Verify(cached, checksum)
+			if err == nil {
+				r.logger.Info("using cached archive", "archive", cached)
+				return r.core.Extract(cached, staged)
+			}
+			r.logger.Warn("cached archive is corrupted, download again", "archive", cached, "error", err)
+			_ = r.store.Remove(checksum)
+		}
+	}
+
+	// 边下载边解压(仅支持tar.gz)
+	if r.o.stream && !archive.Local {
+		if strings.HasSuffix(archive.Filename, ".tar.gz") {
+			return r.core.DownloadExtract(archive.URL, staged, checksum)
+		}
+		r.logger.Info("stream extraction not supported, fall back to download", "archive", archive.Filename)
+	}
+
+	// 下载版本(本地压缩包仅校验,下载中断时保留在下载目录以便续传)
+	tarName := archive.URL
+	if archive.Local {
+		if err := r.core.Verify(tarName, checksum); err != nil {
+			return err
+		}
+	} else {
+		downloaded, err := r.core.Download(archive.URL, r.o.downloadsDir, checksum)
+		if err != nil {
+			return err
+		}
+		tarName = r.storeArchive(downloaded, checksum, archive.Version)
+	}
+
+	return r.core.Extract(tarName, staged)
+}
+
+// storeArchive 将下载的压缩包存入缓存,返回压缩包路径(存入失败时返回原路径)
+func (r *Runtime) storeArchive(downloaded string, checksum string, version string) string {
+	sum := checksum
+	if sum == "" {
+		hashed, err := core.HashFile(downloaded)
+		if err != nil {
+			r.logger.Debug("hash archive failed", "archive", downloaded, "error", err)
+			return downloaded
+		}
+		sum = hashed
+	}
+
+	stored, err := r.store.Put(downloaded, sum, version)
+	if err != nil {
+		r.logger.Debug("store archive failed", "archive", downloaded, "error", err)
+		return downloaded
+	}
+	return stored
+}
+
+// pruneArchives 按缓存限制清理压缩包
+func (r *Runtime) pruneArchives() {
+	pruned, err := r.store.Prune()
+	if err != nil {
+		r.logger.Debug("prune archives failed", "error", err)
+	}
+	for _, entry := range pruned {
+		r.logger.Debug("pruned archive", "version", entry.Version, "sha256", entry.SHA256)
+	}
+}
+
+// newStage 创建暂存目录(同时清理之前中断残留的暂存目录)
+func (r *Runtime) newStage(version string) (string, error) {
+	entries, err := os.ReadDir(r.o.stagingDir)
+	if err == nil {
+		for _, entry := range entries {
+			stale := filepath.Join(r.o.stagingDir, entry.Name())
+			r.logger.Debug("remove stale staging dir", "dir", stale)
+			_ = os.RemoveAll(stale)
+		}
+	}
+
+	if err := os.MkdirAll(r.o.stagingDir, 0755); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(r.o.stagingDir, version+"-")
+}
+
+// commitStage 校验暂存版本后移动到版本目录(expect为空时不校验 go version 输出的版本)
+func (r *Runtime) commitStage(staged string, version string, expect string) error {
+	if err := r.core.Validate(filepath.Join(staged, "go"), expect); err != nil {
+		return err
+	}
+
+	// 记录文件哈希列表(用于 gvm verify 校验)
+	tree, err := core.HashTree(filepath.Join(staged, "go"))
 	if err != nil {
 		return err
 	}
+	if err := core.WriteTree(filepath.Join(staged, treeFile), tree); err != nil {
+		return err
+	}
 
-	// 解压版本
+	if err := os.MkdirAll(r.o.versionsDir, 0755); err != nil {
+		return err
+	}
 	dst := filepath.Join(r.o.versionsDir, version)
-	if err := r.core.Extract(tarName, dst); err != nil {
-		_ = os.RemoveAll(dst)
+	if fileop.Exist(dst) {
+		// 清理之前安装残留的不完整版本
+		r.logger.Debug("remove incomplete version dir", "dir", dst)
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+	}
+	return os.Rename(staged, dst)
+}
+
+// checksum 获取版本压缩包的校验和
+func (r *Runtime) checksum(archive *source.Archive) (string, error) {
+	if r.o.skipChecksum {
+		r.logger.Warn("!!! CHECKSUM VERIFICATION DISABLED: the downloaded archive will NOT be verified !!!",
+			"version", archive.Version)
+		return "", nil
+	}
+
+	if archive.SHA256 == "" {
+		return "", fmt.Errorf("no checksum found for %s (use --insecure-skip-checksum to bypass)", archive.Filename)
+	}
+	r.logger.Debug("expected checksum", "version", archive.Version, "sha256", archive.SHA256)
+	return archive.SHA256, nil
+}
+
+// Uninstall 卸载指定版本(支持同时卸载多个版本)
+func (r *Runtime) Uninstall(versions ...string) error {
+	if len(versions) == 0 {
+		return fmt.Errorf("version is required")
+	}
+
+	unlock, err := r.lock()
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	// 安装版本
-	if err := r.Use(version); err != nil {
-		_ = os.RemoveAll(dst)
+	if err := r.uninstall(versions); err != nil {
 		return err
 	}
+	if !r.o.dryRun {
+		r.rehashIfEnabled()
+	}
+	return nil
+}
 
+func (r *Runtime) uninstall(versions []string) (err error) {
+	// 1) 检查版本(全部检查通过后才执行删除)
+	cv := r.CurrentVersion()
+	targets := make([]string, 0, len(versions))
+	seen := make(map[string]bool)
+	for _, version := range versions {
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+
+		if err := checkName(version); err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(r.o.versionsDir, version)); err != nil {
+			return fmt.Errorf("version %s is not installed", version)
+		}
+		if version == cv && !r.o.force {
+			return fmt.Errorf("version %s is in use, use --force to uninstall it", version)
+		}
+		targets = append(targets, version)
+	}
+
+	// 2) 卸载了当前版本时修复当前版本软链接(删除中途失败时同样修复,避免软链接悬空)
+	if seen[cv] && !r.o.dryRun {
+		defer func() {
+			if repairErr := r.repairCurrent(seen); repairErr != nil && err == nil {
+				err = repairErr
+			}
+		}()
+	}
+
+	// 3) 删除版本
+	var total int64
+	for _, version := range targets {
+		dir := filepath.Join(r.o.versionsDir, version)
+		size, err := fileop.DirSize(dir)
+		if err != nil {
+			r.logger.Debug("calculate size failed", "dir", dir, "error", err)
+		}
+		total += size
+
+		// 链接版本仅取消注册,不删除外部GOROOT
+		if goroot, ok := r.LinkedVersion(version); ok {
+			if r.o.dryRun {
+				r.logger.Info("would unregister", "version", version, "goroot", goroot)
+				continue
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				return err
+			}
+			r.logger.Info("unregistered", "version", version, "goroot", goroot)
+			continue
+		}
+
+		if r.o.dryRun {
+			r.logger.Info("would remove", "version", version, "dir", dir, "size", fileop.HumanSize(size))
+			continue
+		}
+
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		r.logger.Info("uninstalled", "version", version, "size", fileop.HumanSize(size))
+	}
+
+	if r.o.dryRun {
+		r.logger.Info("dry run, nothing removed", "freed", fileop.HumanSize(total))
+		return nil
+	}
+	r.logger.Info("uninstall completed", "freed", fileop.HumanSize(total))
 	return nil
 }
 
-// Uninstall 卸载指定版本
-func (r *Runtime) Uninstall(version string) error {
+// checkName 校验版本名称(防止版本名越界到版本目录之外)
+func checkName(name string) error {
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return fmt.Errorf("invalid version %q", name)
+	}
+	return nil
+}
+
+// repairCurrent 修复当前版本软链接(切换到已安装的最新版本,否则清理),exclude为卸载的版本
+// 优先选择最新的正式版本,其次是预发布版本,最后才是自定义名称的版本(源码构建、链接等)
+func (r *Runtime) repairCurrent(exclude map[string]bool) error {
+	versions, err := r.LocalVersions()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, accept := range []func(*version.Version, error) bool{
+		func(v *version.Version, err error) bool { return err == nil && v.Kind == version.KindRelease },
+		func(v *version.Version, err error) bool { return err == nil },
+		func(v *version.Version, err error) bool { return true },
+	} {
+		for i := len(versions) - 1; i >= 0; i-- {
+			if exclude[versions[i]] || !r.ExistVersion(versions[i]) || !accept(version.Parse(versions[i])) {
+				continue
+			}
+			r.logger.Info("switch current version", "version", versions[i])
+			return r.use(versions[i])
+		}
+	}
+
+	_ = os.RemoveAll(r.o.currentGoDir)
+	_ = os.RemoveAll(r.o.currentBinDir)
+	r.logger.Info("no version installed, current version cleared")
 	return nil
 }
 
@@ -165,38 +613,34 @@ func (r *Runtime) List(filter string) error {
 	}
 
 	// 本地版本列举
-	entries, err := os.ReadDir(r.o.versionsDir)
+	sortedVersions, err := r.LocalVersions()
 	if err != nil {
 		return err
 	}
 
-	versions := make([]string, 0, len(entries))
-	for _, entry := range entries {
-		info, err := entry.Info()
-		if err != nil {
-			return err
-		}
-		versions = append(versions, info.Name())
-	}
-
-	// 标记当前版本
-	cv := r.CurrentVersion()
-	sortedVersions, err := r.core.SortVersions(versions)
+	// 标记生效版本(会话版本 > 项目版本 > 全局版本)
+	cv, origin, err := r.EffectiveVersion()
 	if err != nil {
-		return err
+		r.logger.Debug("resolve effective version failed", "error", err)
+		cv, origin = r.CurrentVersion(), OriginGlobal
 	}
 
 	for _, version := range sortedVersions {
+		line := version
 		if version == cv {
-			r.logger.Info(version + " *")
-		} else {
-			r.logger.Info(version)
+			line += " *"
+		}
+		// 标记链接版本
+		if goroot, ok := r.LinkedVersion(version); ok {
+			r.logger.Info(line, "linked", goroot)
+			continue
 		}
+		r.logger.Info(line)
 	}
 	r.logger.Info("")
 
 	if cv != "" {
-		r.logger.Info("current", "version", cv)
+		r.logger.Info("current", "version", cv, "origin", origin)
 	}
 
 	return nil
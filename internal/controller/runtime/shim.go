@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/util/fileop"
+)
+
+// Rehash 根据已安装版本中的工具重新生成垫片
+func (r *Runtime) Rehash() error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return r.rehash()
+}
+
+func (r *Runtime) rehash() error {
+	tools, err := r.shimTools()
+	if err != nil {
+		return err
+	}
+	gvm, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	root, err := filepath.Abs(r.o.rootDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(r.o.shimsDir, 0755); err != nil {
+		return err
+	}
+
+	// 生成垫片
+	for tool := range tools {
+		fp := filepath.Join(r.o.shimsDir, tool+shimExt)
+		// #nosec G306
+		if err := os.WriteFile(fp, []byte(shimScript(gvm, root, tool)), 0755); err != nil {
+			return err
+		}
+	}
+
+	// 清理已不存在的工具的垫片
+	entries, err := os.ReadDir(r.o.shimsDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !tools[strings.TrimSuffix(entry.Name(), shimExt)] {
+			r.logger.Debug("remove stale shim", "shim", entry.Name())
+			_ = os.Remove(filepath.Join(r.o.shimsDir, entry.Name()))
+		}
+	}
+
+	r.logger.Info("rehashed", "shims", len(tools), "dir", r.o.shimsDir)
+	return nil
+}
+
+// rehashIfEnabled 已启用垫片时重新生成垫片(安装新版本后调用)
+func (r *Runtime) rehashIfEnabled() {
+	if !fileop.Exist(r.o.shimsDir) {
+		return
+	}
+	if err := r.rehash(); err != nil {
+		r.logger.Warn("rehash failed, run gvm rehash manually", "error", err)
+	}
+}
+
+// shimTools 列举所有已安装版本 go/bin 下的工具
+func (r *Runtime) shimTools() (map[string]bool, error) {
+	versions, err := r.LocalVersions()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	tools := make(map[string]bool)
+	for _, version := range versions {
+		entries, err := os.ReadDir(filepath.Join(r.o.versionsDir, version, "go", "bin"))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), core.FileExt) {
+				continue
+			}
+			tools[strings.TrimSuffix(entry.Name(), core.FileExt)] = true
+		}
+	}
+	return tools, nil
+}
+
+// Shim 使用生效版本执行工具(由垫片调用)
+func (r *Runtime) Shim(tool string, args []string) error {
+	version, origin, err := r.EffectiveVersion()
+	if err != nil {
+		return err
+	}
+
+	goroot := filepath.Join(r.o.versionsDir, version, "go")
+	bin := filepath.Join(goroot, "bin", tool+core.FileExt)
+	if _, err := os.Stat(bin); err != nil {
+		return fmt.Errorf("%s is not available in go %s (%s version)", tool, version, origin)
+	}
+	return r.core.Exec(bin, args, toolEnv(goroot))
+}
+
+// toolEnv 生成执行指定版本工具的环境变量(设置GOROOT并将其bin目录放在PATH最前,extra为额外设置的 KEY=VALUE)
+func toolEnv(goroot string, extra ...string) []string {
+	override := map[string]bool{"GOROOT": true, "PATH": true}
+	for _, kv := range extra {
+		name, _, _ := strings.Cut(kv, "=")
+		override[strings.ToUpper(name)] = true
+	}
+
+	env := make([]string, 0, len(os.Environ())+len(extra)+2)
+	var path string
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if strings.EqualFold(name, "PATH") {
+			path = value
+		}
+		if override[strings.ToUpper(name)] {
+			continue
+		}
+		env = append(env, kv)
+	}
+
+	bin := filepath.Join(goroot, "bin")
+	if path != "" {
+		bin += string(os.PathListSeparator) + path
+	}
+	env = append(env, "GOROOT="+goroot, "PATH="+bin)
+	return append(env, extra...)
+}
@@ -0,0 +1,14 @@
+//go:build linux
+// +build linux
+
+package runtime
+
+// shimExt 垫片文件扩展名
+const shimExt = ""
+
+// shimScript 垫片脚本(通过 gvm shim 按调用时的版本执行工具)
+func shimScript(gvm string, root string, tool string) string {
+	return "#!/bin/sh\n" +
+		"# gvm shim, regenerate with gvm rehash\n" +
+		"GVM_ROOT=" + quotePosix(root) + " exec " + quotePosix(gvm) + " shim " + quotePosix(tool) + " \"$@\"\n"
+}
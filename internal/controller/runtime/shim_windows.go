@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package runtime
+
+// shimExt 垫片文件扩展名
+const shimExt = ".cmd"
+
+// shimScript 垫片脚本(通过 gvm shim 按调用时的版本执行工具)
+func shimScript(gvm string, root string, tool string) string {
+	return "@echo off\r\n" +
+		"rem gvm shim, regenerate with gvm rehash\r\n" +
+		"setlocal\r\n" +
+		"set \"GVM_ROOT=" + root + "\"\r\n" +
+		"\"" + gvm + "\" shim " + tool + " %*\r\n"
+}
@@ -0,0 +1,13 @@
+package source
+
+// ISource 版本源接口
+type ISource interface {
+	// Name 版本源名称
+	Name() string
+	// Releases 列举发布版本
+	Releases() ([]Release, error)
+	// Resolve 解析当前平台指定版本的压缩包
+	Resolve(version string) (*Archive, error)
+	// ResolveSource 解析指定版本的源码压缩包
+	ResolveSource(version string) (*Archive, error)
+}
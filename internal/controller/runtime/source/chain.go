@@ -0,0 +1,75 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+var _ ISource = (*Chain)(nil)
+
+// Chain 按顺序回退的版本源链
+type Chain struct {
+	logger  log.ILog  // 日志接口
+	sources []ISource // 版本源(按优先级排序)
+}
+
+func NewChain(logger log.ILog, sources ...ISource) ISource {
+	return &Chain{
+		logger:  logger,
+		sources: sources,
+	}
+}
+
+func (c *Chain) Name() string {
+	return "chain"
+}
+
+// Releases 返回第一个可用版本源的发布版本
+func (c *Chain) Releases() ([]Release, error) {
+	errs := make([]error, 0, len(c.sources))
+	for _, s := range c.sources {
+		releases, err := s.Releases()
+		if err == nil && len(releases) > 0 {
+			c.logger.Debug("list releases", "source", s.Name(), "count", len(releases))
+			return releases, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("no release found")
+		}
+		c.logger.Debug("list releases failed, try next source", "source", s.Name(), "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+	}
+	return nil, fmt.Errorf("list releases failed: %w", errors.Join(errs...))
+}
+
+// Resolve 返回第一个可解析版本源的压缩包
+func (c *Chain) Resolve(version string) (*Archive, error) {
+	errs := make([]error, 0, len(c.sources))
+	for _, s := range c.sources {
+		archive, err := s.Resolve(version)
+		if err == nil {
+			c.logger.Debug("resolve archive", "source", s.Name(), "url", archive.URL)
+			return archive, nil
+		}
+		c.logger.Debug("resolve archive failed, try next source", "source", s.Name(), "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+	}
+	return nil, fmt.Errorf("resolve version %s failed: %w", version, errors.Join(errs...))
+}
+
+// ResolveSource 返回第一个可解析版本源的源码压缩包
+func (c *Chain) ResolveSource(version string) (*Archive, error) {
+	errs := make([]error, 0, len(c.sources))
+	for _, s := range c.sources {
+		archive, err := s.ResolveSource(version)
+		if err == nil {
+			c.logger.Debug("resolve source archive", "source", s.Name(), "url", archive.URL)
+			return archive, nil
+		}
+		c.logger.Debug("resolve source archive failed, try next source", "source", s.Name(), "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+	}
+	return nil, fmt.Errorf("resolve source of version %s failed: %w", version, errors.Join(errs...))
+}
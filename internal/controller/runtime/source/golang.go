@@ -0,0 +1,99 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/httpcli"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+var _ ISource = (*Golang)(nil)
+
+// Golang 官方发布版本索引(go.dev/dl/?mode=json&include=all)
+type Golang struct {
+	logger log.ILog // 日志接口
+	repo   string   // 仓库地址
+
+	o *Option // 选项
+}
+
+func NewGolang(logger log.ILog, repo string, opts ...OptionFunc) ISource {
+	o := &Option{}
+	o.Apply(opts)
+
+	return &Golang{
+		logger: logger,
+		repo:   repo,
+		o:      o,
+	}
+}
+
+func (g *Golang) Name() string {
+	return config.SourceGolang + "=" + g.repo
+}
+
+// Releases 获取发布版本索引
+func (g *Golang) Releases() ([]Release, error) {
+	client := httpcli.NewClient(httpcli.WithDebug(g.o.verbose))
+	response, err := client.Get(g.repo, map[string]string{"mode": "json", "include": "all"})
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get release index failed, status code: %d", response.StatusCode())
+	}
+
+	releases := make([]Release, 0)
+	if err := json.Unmarshal(response.Body(), &releases); err != nil {
+		return nil, fmt.Errorf("parse release index failed: %w", err)
+	}
+	return releases, nil
+}
+
+// Resolve 从发布版本索引解析压缩包
+func (g *Golang) Resolve(version string) (*Archive, error) {
+	releases, err := g.Releases()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := findArchive(releases, version)
+	if err != nil {
+		return nil, err
+	}
+	return g.archive(version, file)
+}
+
+// ResolveSource 从发布版本索引解析源码压缩包
+func (g *Golang) ResolveSource(version string) (*Archive, error) {
+	releases, err := g.Releases()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := findSource(releases, version)
+	if err != nil {
+		return nil, err
+	}
+	return g.archive(version, file)
+}
+
+// archive 生成发布文件的下载信息
+func (g *Golang) archive(version string, file *File) (*Archive, error) {
+	downloadURL, err := url.JoinPath(g.repo, file.Filename)
+	if err != nil {
+		return nil, err
+	}
+	return &Archive{
+		Version:  version,
+		Filename: file.Filename,
+		URL:      downloadURL,
+		SHA256:   strings.ToLower(file.SHA256),
+		Size:     file.Size,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/httpcli"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+var _ ISource = (*HTTPDir)(nil)
+
+// hrefRegexp 目录列表页面中的链接
+var hrefRegexp = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+
+// HTTPDir HTTP目录列表(Artifactory/Nexus 等镜像)
+type HTTPDir struct {
+	logger log.ILog // 日志接口
+	dirURL string   // 目录地址
+
+	o *Option // 选项
+}
+
+func NewHTTPDir(logger log.ILog, dirURL string, opts ...OptionFunc) ISource {
+	o := &Option{}
+	o.Apply(opts)
+
+	return &HTTPDir{
+		logger: logger,
+		dirURL: dirURL,
+		o:      o,
+	}
+}
+
+func (h *HTTPDir) Name() string {
+	return config.SourceHTTP + "=" + h.dirURL
+}
+
+// Releases 解析目录列表中的压缩包
+func (h *HTTPDir) Releases() ([]Release, error) {
+	filenames, err := h.listing()
+	if err != nil {
+		return nil, err
+	}
+	return releasesFromFilenames(filenames), nil
+}
+
+// Resolve 解析压缩包(校验和取自 <archive>.sha256 文件)
+func (h *HTTPDir) Resolve(version string) (*Archive, error) {
+	releases, err := h.Releases()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := findArchive(releases, version)
+	if err != nil {
+		return nil, err
+	}
+	return remoteArchive(h.logger, h.o.verbose, h.dirURL, file.Filename, version)
+}
+
+// ResolveSource 解析源码压缩包
+func (h *HTTPDir) ResolveSource(version string) (*Archive, error) {
+	filenames, err := h.listing()
+	if err != nil {
+		return nil, err
+	}
+
+	filename := SourceName(version)
+	if !slices.Contains(filenames, filename) {
+		return nil, fmt.Errorf("version %s has no source archive", version)
+	}
+	return remoteArchive(h.logger, h.o.verbose, h.dirURL, filename, version)
+}
+
+// listing 获取目录列表中的文件名
+func (h *HTTPDir) listing() ([]string, error) {
+	client := httpcli.NewClient(httpcli.WithDebug(h.o.verbose))
+	response, err := client.Get(h.dirURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get directory listing failed, status code: %d", response.StatusCode())
+	}
+
+	filenames := make([]string, 0)
+	for _, matches := range hrefRegexp.FindAllStringSubmatch(response.String(), -1) {
+		link, err := url.PathUnescape(strings.TrimSuffix(matches[1], "/"))
+		if err != nil {
+			continue
+		}
+		filenames = append(filenames, path.Base(link))
+	}
+	return filenames, nil
+}
@@ -0,0 +1,29 @@
+package source
+
+type Option struct {
+	verbose bool   // 是否显示详细信息
+	repo    string // 压缩包下载仓库(仅tag源使用)
+}
+
+func (o *Option) Apply(opts []OptionFunc) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// 选项
+type OptionFunc func(o *Option)
+
+// WithVerbose 设置是否显示详细信息
+func WithVerbose(verbose bool) OptionFunc {
+	return func(o *Option) {
+		o.verbose = verbose
+	}
+}
+
+// WithRepo 设置压缩包下载仓库
+func WithRepo(repo string) OptionFunc {
+	return func(o *Option) {
+		o.repo = repo
+	}
+}
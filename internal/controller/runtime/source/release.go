@@ -0,0 +1,186 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
+)
+
+// 发布文件类型
+const (
+	KindArchive   = "archive"
+	KindInstaller = "installer"
+	KindSource    = "source"
+)
+
+// Release 发布版本信息(对应 go.dev/dl/?mode=json 格式)
+type Release struct {
+	Version string `json:"version"` // 版本号(如 go1.22.4)
+	Stable  bool   `json:"stable"`  // 是否稳定版本
+	Files   []File `json:"files"`   // 发布文件
+}
+
+// File 发布文件信息
+type File struct {
+	Filename string `json:"filename"` // 文件名
+	OS       string `json:"os"`       // 操作系统
+	Arch     string `json:"arch"`     // 架构
+	Version  string `json:"version"`  // 版本号
+	SHA256   string `json:"sha256"`   // 校验和
+	Size     int64  `json:"size"`     // 文件大小
+	Kind     string `json:"kind"`     // 文件类型(archive/installer/source)
+}
+
+// Archive 解析后的压缩包
+type Archive struct {
+	Version  string // 版本号(不带go前缀)
+	Filename string // 文件名
+	URL      string // 下载地址(本地源为文件路径)
+	SHA256   string // 校验和(为空表示未知)
+	Size     int64  // 文件大小(为0表示未知)
+	Local    bool   // 是否为本地文件
+}
+
+// Name 版本名(去掉go前缀)
+func (r *Release) Name() string {
+	return strings.TrimPrefix(r.Version, "go")
+}
+
+// Archive 获取指定平台的压缩包文件,不存在时返回nil
+func (r *Release) Archive(goos string, goarch string) *File {
+	for i := range r.Files {
+		file := &r.Files[i]
+		if file.Kind == KindArchive && file.OS == goos && file.Arch == goarch {
+			return file
+		}
+	}
+	return nil
+}
+
+// Source 获取源码压缩包文件,不存在时返回nil
+func (r *Release) Source() *File {
+	for i := range r.Files {
+		if r.Files[i].Kind == KindSource {
+			return &r.Files[i]
+		}
+	}
+	return nil
+}
+
+// SourceName 源码压缩包文件名
+func SourceName(version string) string {
+	return fmt.Sprintf("go%s.src.tar.gz", version)
+}
+
+// ArchiveName 当前平台的压缩包文件名
+func ArchiveName(version string) string {
+	return fmt.Sprintf("go%s.%s-%s%s", version, runtime.GOOS, runtime.GOARCH, core.TarExt)
+}
+
+// archiveNameRegexp 压缩包文件名格式(如 go1.22.4.linux-amd64.tar.gz)
+var archiveNameRegexp = regexp.MustCompile(`^go(.+)\.([a-z0-9]+)-([a-z0-9]+)\.(tar\.gz|zip)$`)
+
+// releasesFromFilenames 根据压缩包文件名生成发布版本
+func releasesFromFilenames(filenames []string) []Release {
+	index := make(map[string]*Release)
+	for _, filename := range filenames {
+		matches := archiveNameRegexp.FindStringSubmatch(filename)
+		if len(matches) == 0 {
+			continue
+		}
+
+		version := matches[1]
+		release, ok := index[version]
+		if !ok {
+			release = &Release{
+				Version: "go" + version,
+				Stable:  !core.IsBetaOrRC(version),
+			}
+			index[version] = release
+		}
+		release.Files = append(release.Files, File{
+			Filename: filename,
+			OS:       matches[2],
+			Arch:     matches[3],
+			Version:  "go" + version,
+			Kind:     KindArchive,
+		})
+	}
+
+	releases := make([]Release, 0, len(index))
+	for _, release := range index {
+		releases = append(releases, *release)
+	}
+	sortReleases(releases)
+	return releases
+}
+
+// sortReleases 按版本号升序排序(与 SortVersions 一致,无法解析的版本按名称排在最后)
+func sortReleases(releases []Release) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		vi, erri := version.Parse(releases[i].Name())
+		vj, errj := version.Parse(releases[j].Name())
+		switch {
+		case erri == nil && errj == nil:
+			return vi.Compare(vj) < 0
+		case erri == nil || errj == nil:
+			return erri == nil
+		default:
+			return releases[i].Version < releases[j].Version
+		}
+	})
+}
+
+// findArchive 从发布版本中查找当前平台指定版本的压缩包
+func findArchive(releases []Release, version string) (*File, error) {
+	for _, release := range releases {
+		if release.Name() != version {
+			continue
+		}
+		if file := release.Archive(runtime.GOOS, runtime.GOARCH); file != nil {
+			return file, nil
+		}
+		return nil, fmt.Errorf("version %s has no archive for %s/%s", version, runtime.GOOS, runtime.GOARCH)
+	}
+	return nil, fmt.Errorf("version %s not found", version)
+}
+
+// findSource 从发布版本中查找指定版本的源码压缩包
+func findSource(releases []Release, version string) (*File, error) {
+	for _, release := range releases {
+		if release.Name() != version {
+			continue
+		}
+		if file := release.Source(); file != nil {
+			return file, nil
+		}
+		return nil, fmt.Errorf("version %s has no source archive", version)
+	}
+	return nil, fmt.Errorf("version %s not found", version)
+}
+
+// ParseChecksum 解析sha256校验和(兼容 sha256sum 输出格式)
+func ParseChecksum(content string) (string, error) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 || !isSHA256(fields[0]) {
+		return "", fmt.Errorf("invalid sha256 checksum %q", strings.TrimSpace(content))
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+func isSHA256(sum string) bool {
+	if len(sum) != 64 {
+		return false
+	}
+	for _, ch := range strings.ToLower(sum) {
+		if (ch < '0' || ch > '9') && (ch < 'a' || ch > 'f') {
+			return false
+		}
+	}
+	return true
+}
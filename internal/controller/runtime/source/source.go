@@ -0,0 +1,37 @@
+package source
+
+import (
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+// New 根据配置创建版本源(多个版本源按顺序回退)
+func New(logger log.ILog, c *config.Config) ISource {
+	specs := c.Sources
+	if len(specs) == 0 {
+		specs = []string{config.SourceGolang}
+	}
+
+	sources := make([]ISource, 0, len(specs))
+	for _, spec := range specs {
+		// 配置已校验,忽略错误
+		kind, location, _ := c.ParseSource(spec)
+		opts := []OptionFunc{WithVerbose(c.Verbose), WithRepo(c.Repo)}
+
+		switch kind {
+		case config.SourceGolang:
+			sources = append(sources, NewGolang(logger, location, opts...))
+		case config.SourceTag:
+			sources = append(sources, NewTag(logger, location, opts...))
+		case config.SourceHTTP:
+			sources = append(sources, NewHTTPDir(logger, location, opts...))
+		case config.SourceLocal:
+			sources = append(sources, NewLocal(logger, location, opts...))
+		}
+	}
+
+	if len(sources) == 1 {
+		return sources[0]
+	}
+	return NewChain(logger, sources...)
+}
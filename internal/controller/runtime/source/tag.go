@@ -0,0 +1,117 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/httpcli"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+var _ ISource = (*Tag)(nil)
+
+// Tag git标签列表(如 gobrew 维护的 golang-tags.json),压缩包从仓库下载
+type Tag struct {
+	logger log.ILog // 日志接口
+	tagURL string   // 标签列表地址
+
+	o *Option // 选项
+}
+
+type tagRef struct {
+	Ref string `json:"ref"`
+}
+
+func NewTag(logger log.ILog, tagURL string, opts ...OptionFunc) ISource {
+	o := &Option{}
+	o.Apply(opts)
+
+	return &Tag{
+		logger: logger,
+		tagURL: tagURL,
+		o:      o,
+	}
+}
+
+func (t *Tag) Name() string {
+	return config.SourceTag + "=" + t.tagURL
+}
+
+// Releases 获取标签对应的版本(标签不包含平台信息,默认当前平台存在压缩包)
+func (t *Tag) Releases() ([]Release, error) {
+	client := httpcli.NewClient(httpcli.WithDebug(t.o.verbose))
+	response, err := client.Get(t.tagURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("get remote tags failed, status code: %d", response.StatusCode())
+	}
+
+	tags := make([]tagRef, 0)
+	if err := json.Unmarshal(response.Body(), &tags); err != nil {
+		return nil, err
+	}
+
+	// 去除refs/tags/ 前缀和 go前缀
+	filenames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ref := strings.ReplaceAll(tag.Ref, "refs/tags/", "")
+		if strings.HasPrefix(ref, "go") {
+			filenames = append(filenames, ArchiveName(strings.TrimPrefix(ref, "go")))
+		}
+	}
+	return releasesFromFilenames(filenames), nil
+}
+
+// Resolve 从仓库解析压缩包(校验和取自 <archive>.sha256 文件)
+func (t *Tag) Resolve(version string) (*Archive, error) {
+	if t.o.repo == "" {
+		return nil, fmt.Errorf("no download repo for tag source")
+	}
+	return remoteArchive(t.logger, t.o.verbose, t.o.repo, ArchiveName(version), version)
+}
+
+// ResolveSource 从仓库解析源码压缩包
+func (t *Tag) ResolveSource(version string) (*Archive, error) {
+	if t.o.repo == "" {
+		return nil, fmt.Errorf("no download repo for tag source")
+	}
+	return remoteArchive(t.logger, t.o.verbose, t.o.repo, SourceName(version), version)
+}
+
+// remoteArchive 生成远程压缩包的下载信息(校验和取自 <archive>.sha256 文件)
+func remoteArchive(logger log.ILog, verbose bool, base string, filename string, version string) (*Archive, error) {
+	downloadURL, err := url.JoinPath(base, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := fetchChecksum(verbose, downloadURL+".sha256")
+	if err != nil {
+		logger.Debug("fetch checksum failed", "url", downloadURL, "error", err)
+	}
+	return &Archive{
+		Version:  version,
+		Filename: filename,
+		URL:      downloadURL,
+		SHA256:   checksum,
+	}, nil
+}
+
+// fetchChecksum 获取远程sha256校验和文件
+func fetchChecksum(verbose bool, sumURL string) (string, error) {
+	client := httpcli.NewClient(httpcli.WithDebug(verbose))
+	response, err := client.Get(sumURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return "", fmt.Errorf("get %s failed, status code: %d", sumURL, response.StatusCode())
+	}
+	return ParseChecksum(response.String())
+}
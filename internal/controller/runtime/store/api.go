@@ -0,0 +1,20 @@
+package store
+
+// IStore 压缩包存储接口(按sha256寻址)
+type IStore interface {
+	// Get 获取压缩包路径,不存在时返回false
+	Get(sum string) (string, bool)
+	// Find 按文件名查找最近使用的压缩包,不存在时返回false
+	Find(name string) (*Entry, bool)
+	// Put 将压缩包移动到存储中,返回存储后的路径
+	Put(src string, sum string, version string) (string, error)
+	// Remove 删除压缩包
+	Remove(sum string) error
+
+	// List 列举压缩包(最近使用的在前)
+	List() ([]Entry, error)
+	// Prune 按大小及过期时间清理压缩包,返回被清理的压缩包
+	Prune() ([]Entry, error)
+	// Clear 清理全部压缩包,返回被清理的压缩包
+	Clear() ([]Entry, error)
+}
@@ -0,0 +1,18 @@
+package store
+
+import "time"
+
+type Option struct {
+	dir     string        // 存储目录
+	maxSize int64         // 最大总大小(字节,不大于0时不限制)
+	maxAge  time.Duration // 最长未使用时间(不大于0时不限制)
+}
+
+func (o *Option) Apply(opts []OptionFunc) {
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+// 选项
+type OptionFunc func(o *Option)
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+// entryFile 压缩包信息文件
+const entryFile = "entry.json"
+
+var _ IStore = (*Store)(nil)
+
+// Entry 压缩包信息
+type Entry struct {
+	SHA256   string    `json:"sha256"`    // 校验和
+	Name     string    `json:"name"`      // 文件名
+	Version  string    `json:"version"`   // 版本号
+	Size     int64     `json:"size"`      // 文件大小
+	Created  time.Time `json:"created"`   // 存入时间
+	LastUsed time.Time `json:"last_used"` // 最近使用时间
+
+	path string // 压缩包路径
+}
+
+// Path 压缩包路径
+func (e *Entry) Path() string {
+	return e.path
+}
+
+// Store 压缩包存储(目录结构: <dir>/<sha256>/{<filename>,entry.json})
+type Store struct {
+	logger log.ILog // 日志接口
+
+	o *Option // 选项
+}
+
+func NewStore(logger log.ILog, conf *config.Config, opts ...OptionFunc) IStore {
+	o := &Option{
+		dir:     filepath.Join(conf.RootDir, "archives"),
+		maxSize: conf.ArchiveMaxSize << 20,
+		maxAge:  conf.ArchiveMaxAge,
+	}
+	o.Apply(opts)
+
+	return &Store{
+		logger: logger,
+		o:      o,
+	}
+}
+
+func (s *Store) Get(sum string) (string, bool) {
+	entry, err := s.load(strings.ToLower(sum))
+	if err != nil {
+		return "", false
+	}
+	if _, err := os.Stat(entry.path); err != nil {
+		return "", false
+	}
+
+	// 更新最近使用时间
+	entry.LastUsed = time.Now()
+	if err := s.save(entry); err != nil {
+		s.logger.Debug("update archive entry failed", "sha256", sum, "error", err)
+	}
+	return entry.path, true
+}
+
+func (s *Store) Find(name string) (*Entry, bool) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, false
+	}
+	for i := range entries {
+		if entries[i].Name == name {
+			return &entries[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *Store) Put(src string, sum string, version string) (string, error) {
+	sum = strings.ToLower(sum)
+	dir := filepath.Join(s.o.dir, sum)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+	dst := filepath.Join(dir, filepath.Base(src))
+	if err := os.Rename(src, dst); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	entry := &Entry{
+		SHA256:   sum,
+		Name:     filepath.Base(src),
+		Version:  version,
+		Size:     info.Size(),
+		Created:  now,
+		LastUsed: now,
+		path:     dst,
+	}
+	return dst, s.save(entry)
+}
+
+func (s *Store) Remove(sum string) error {
+	return os.RemoveAll(filepath.Join(s.o.dir, strings.ToLower(sum)))
+}
+
+func (s *Store) List() ([]Entry, error) {
+	dirs, err := os.ReadDir(s.o.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(dirs))
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := s.load(dir.Name())
+		if err != nil {
+			s.logger.Debug("load archive entry failed", "sha256", dir.Name(), "error", err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+func (s *Store) Prune() ([]Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// 保留最近使用且未过期的压缩包,直到超出大小限制
+	pruned := make([]Entry, 0)
+	var total int64
+	for _, entry := range entries {
+		expired := s.o.maxAge > 0 && time.Since(entry.LastUsed) > s.o.maxAge
+		oversize := s.o.maxSize > 0 && total+entry.Size > s.o.maxSize
+		if !expired && !oversize {
+			total += entry.Size
+			continue
+		}
+
+		if err := s.Remove(entry.SHA256); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned, nil
+}
+
+func (s *Store) Clear() ([]Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(s.o.dir); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// load 读取压缩包信息
+func (s *Store) load(sum string) (*Entry, error) {
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(s.o.dir, sum, entryFile))
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Entry{}
+	if err := json.Unmarshal(content, entry); err != nil {
+		return nil, fmt.Errorf("parse archive entry %s failed: %w", sum, err)
+	}
+	entry.path = filepath.Join(s.o.dir, sum, entry.Name)
+	return entry, nil
+}
+
+// save 保存压缩包信息
+func (s *Store) save(entry *Entry) error {
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.o.dir, entry.SHA256, entryFile), content, 0644)
+}
@@ -0,0 +1,204 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/source"
+)
+
+// treeFile 文件哈希列表文件名(位于版本目录下,兼容 sha256sum 格式)
+const treeFile = ".gvm-files.sha256"
+
+// Verify 校验已安装版本的文件完整性(未指定版本时校验当前版本)
+func (r *Runtime) Verify(version string) error {
+	versions, err := r.verifyTargets(version)
+	if err != nil {
+		return err
+	}
+
+	// 修复时需要修改版本目录
+	if r.o.repair {
+		unlock, err := r.lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	failed := make([]string, 0)
+	for _, v := range versions {
+		ok, err := r.verify(v)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+
+		if r.o.repair {
+			if err := r.repair(v); err != nil {
+				return fmt.Errorf("repair %s failed: %w", v, err)
+			}
+			continue
+		}
+		failed = append(failed, v)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("verification failed for %s (use --repair to reinstall from the cached archive)",
+			strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// verifyTargets 获取需要校验的版本
+func (r *Runtime) verifyTargets(version string) ([]string, error) {
+	if r.o.all {
+		if version != "" {
+			return nil, fmt.Errorf("version %s cannot be used with --all", version)
+		}
+		return r.LocalVersions()
+	}
+
+	if version == "" {
+		version = r.CurrentVersion()
+		if version == core.NoneVersion {
+			return nil, fmt.Errorf("no version in use, specify a version or use --all")
+		}
+	}
+	resolved, err := r.ResolveInstalled(version)
+	if err != nil {
+		return nil, err
+	}
+	return []string{resolved}, nil
+}
+
+// verify 对比文件哈希列表,返回是否一致
+func (r *Runtime) verify(version string) (bool, error) {
+	if goroot, ok := r.LinkedVersion(version); ok {
+		r.logger.Info("linked version, skipped", "version", version, "goroot", goroot)
+		return true, nil
+	}
+
+	dir := filepath.Join(r.o.versionsDir, version)
+	expected, err := core.ReadTree(filepath.Join(dir, treeFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.logger.Info("no file hashes recorded, skipped", "version", version)
+			return true, nil
+		}
+		return false, err
+	}
+	actual, err := core.HashTree(filepath.Join(dir, "go"))
+	if err != nil {
+		return false, err
+	}
+
+	// 对比文件列表
+	var added, modified, missing []string
+	for path, sum := range actual {
+		want, ok := expected[path]
+		switch {
+		case !ok:
+			added = append(added, path)
+		case !strings.EqualFold(want, sum):
+			modified = append(modified, path)
+		}
+	}
+	for path := range expected {
+		if _, ok := actual[path]; !ok {
+			missing = append(missing, path)
+		}
+	}
+
+	if len(added)+len(modified)+len(missing) == 0 {
+		r.logger.Info("verified", "version", version, "files", len(expected))
+		return true, nil
+	}
+	for _, diff := range []struct {
+		kind  string
+		paths []string
+	}{{"added", added}, {"modified", modified}, {"missing", missing}} {
+		sort.Strings(diff.paths)
+		for _, path := range diff.paths {
+			r.logger.Warn(diff.kind, "version", version, "file", path)
+		}
+	}
+	r.logger.Warn("verification failed", "version", version,
+		"added", len(added), "modified", len(modified), "missing", len(missing))
+	return false, nil
+}
+
+// repair 从缓存的压缩包重新安装版本
+func (r *Runtime) repair(version string) error {
+	dir := filepath.Join(r.o.versionsDir, version)
+	m, err := readManifest(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	archive, checksum, err := r.repairArchive(version, m)
+	if err != nil {
+		return err
+	}
+	r.logger.Info("repairing", "version", version, "archive", archive)
+
+	stage, err := r.newStage(version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(stage)
+	}()
+
+	staged := filepath.Join(stage, version)
+	if err := r.core.Verify(archive, checksum); err != nil {
+		return err
+	}
+	if err := r.core.Extract(archive, staged); err != nil {
+		return err
+	}
+	// 保留原安装清单
+	if m != nil {
+		if err := writeManifest(staged, m); err != nil {
+			return err
+		}
+	}
+	if err := r.commitStage(staged, version, version); err != nil {
+		return err
+	}
+	r.logger.Info("repaired", "version", version)
+	return nil
+}
+
+// repairArchive 查找用于修复的压缩包及其校验和
+func (r *Runtime) repairArchive(version string, m *Manifest) (string, string, error) {
+	if m != nil {
+		switch m.Method {
+		case MethodDownload, MethodFile:
+		default:
+			return "", "", fmt.Errorf("version %s was installed by %s, reinstall it manually", version, m.Method)
+		}
+
+		if m.SHA256 != "" {
+			if cached, ok := r.store.Get(m.SHA256); ok {
+				return cached, m.SHA256, nil
+			}
+			// 本地压缩包仍存在时使用原文件
+			if m.Method == MethodFile && r.core.Verify(m.Source, m.SHA256) == nil {
+				return m.Source, m.SHA256, nil
+			}
+		}
+	}
+
+	// 兼容没有安装清单的版本
+	if entry, ok := r.store.Find(source.ArchiveName(version)); ok {
+		return entry.Path(), entry.SHA256, nil
+	}
+	return "", "", fmt.Errorf("no cached archive for %s, uninstall and install it again", version)
+}
@@ -1,18 +1,28 @@
 package runtime
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
-	"sort"
+	goruntime "runtime"
 	"strings"
 
-	"github.com/Masterminds/semver"
-
+	"github.com/justwhenjing/gvm/internal/controller/project"
 	"github.com/justwhenjing/gvm/internal/controller/runtime/core"
-	"github.com/justwhenjing/gvm/internal/util/httpcli"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/resolver"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/source"
+	"github.com/justwhenjing/gvm/internal/controller/runtime/version"
+)
+
+// EnvVersion 指定会话版本的环境变量
+const EnvVersion = "GVM_VERSION"
+
+// 版本来源
+const (
+	OriginSession = "session" // 会话版本(GVM_VERSION环境变量)
+	OriginProject = "project" // 项目版本文件
+	OriginGlobal  = "global"  // 全局版本(current软链接)
 )
 
 // CurrentVersion 查看当前版本
@@ -34,115 +44,109 @@ func (r *Runtime) CurrentVersion() string {
 	return version
 }
 
-// LatestRemoteVersion 获取最新远程版本
+// LatestRemoteVersion 获取最新远程稳定版本
 func (r *Runtime) LatestRemoteVersion() (string, error) {
-	remoteVersions, err := r.RemoteVersions()
-	if err != nil {
-		return "", err
-	}
+	return r.ResolveRemote(resolver.AliasLatest)
+}
 
-	stableVersions := make([]*semver.Version, 0)
-	for _, version := range remoteVersions {
-		// 判断是否支持
-		if core.NotSupportedVersion(version) {
-			continue
+// ResolveRemote 将版本约束或别名解析为远程版本(精确版本规范化后直接返回,如 go1.22.4 => 1.22.4)
+func (r *Runtime) ResolveRemote(spec string) (string, error) {
+	if resolver.IsExact(spec) {
+		v, err := version.Parse(spec)
+		if err != nil {
+			return "", err
 		}
+		return v.String(), nil
+	}
 
-		// 判断是否为beat版本
-		if core.IsBetaOrRC(version) {
-			continue
-		}
+	releases, err := r.RemoteReleases()
+	if err != nil {
+		return "", err
+	}
 
-		v, err := semver.NewVersion(version)
-		if err != nil {
-			r.logger.Debug("parse version %s failed: %w", version, err)
+	candidates := make([]resolver.Candidate, 0, len(releases))
+	for _, release := range releases {
+		if core.NotSupportedVersion(release.Name()) {
 			continue
 		}
-		stableVersions = append(stableVersions, v)
+		candidates = append(candidates, resolver.Candidate{Version: release.Name(), Stable: release.Stable})
 	}
 
-	if len(stableVersions) == 0 {
-		return "", fmt.Errorf("no stable version found in remote versions")
+	version, err := resolver.Resolve(spec, candidates)
+	if err != nil {
+		return "", fmt.Errorf("resolve %q from remote versions failed: %w", spec, err)
 	}
-
-	sort.Sort(semver.Collection(stableVersions))
-
-	return core.FormatVersion(
-		stableVersions[len(stableVersions)-1].String(),
-	), nil
+	r.logResolved(spec, version)
+	return version, nil
 }
 
-type Tag struct {
-	Ref string `json:"ref"`
-}
-
-// RemoteVersions 获取远程版本
-func (r *Runtime) RemoteVersions() ([]string, error) {
-	client := httpcli.NewClient(httpcli.WithDebug(r.o.verbose))
-	response, err := client.Get(r.o.tagURL, nil)
+// RemoteReleases 获取远程发布版本(仅保留当前平台有压缩包的版本)
+func (r *Runtime) RemoteReleases() ([]source.Release, error) {
+	releases, err := r.source.Releases()
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("get remote versions failed, status code: %d", response.StatusCode())
+
+	result := make([]source.Release, 0, len(releases))
+	for _, release := range releases {
+		if release.Archive(goruntime.GOOS, goruntime.GOARCH) == nil {
+			continue
+		}
+		result = append(result, release)
 	}
+	return result, nil
+}
 
-	tags := make([]Tag, 0)
-	if err := json.Unmarshal(response.Body(), &tags); err != nil {
+// RemoteVersions 获取远程版本
+func (r *Runtime) RemoteVersions() ([]string, error) {
+	releases, err := r.RemoteReleases()
+	if err != nil {
 		return nil, err
 	}
 
-	// 去除refs/tags/ 前缀和 go后缀
-	versions := make([]string, 0)
-	for _, tag := range tags {
-		ref := strings.ReplaceAll(tag.Ref, "refs/tags/", "")
-		if strings.HasPrefix(ref, "go") {
-			versions = append(versions, strings.TrimPrefix(ref, "go"))
-		}
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		versions = append(versions, release.Name())
 	}
 	return versions, nil
 }
 
-// GroupVersions 版本分组
-func (r *Runtime) GroupVersions(versions []string) ([]string, map[string][]string, error) {
+// GroupVersions 版本分组(按语言版本分组,组内及组间均按版本升序)
+func (r *Runtime) GroupVersions(names []string) ([]string, map[string][]string, error) {
 	// 1) 分组
-	group := make(map[string][]string)
-	for _, version := range versions {
-		// 分割版本号
-		parts := strings.Split(version, ".")
-		if len(parts) <= 1 {
+	parsed := make(map[string][]*version.Version)
+	langs := make([]*version.Version, 0)
+	for _, name := range names {
+		v, err := version.Parse(name)
+		if err != nil {
+			r.logger.Debug("parse version failed", "version", name, "error", err)
 			continue
 		}
 
-		// 主版本号
-		majorVersion := fmt.Sprintf("%s.%s", parts[0], parts[1])
-		// 带有beta/rc版本号的,直接去掉beta/rc后缀
-		matches := core.MatchBetaOrRC(majorVersion)
-		if len(matches) >= 1 {
-			majorVersion = strings.Split(version, matches[0])[0]
-		}
-
 		// 过滤不支持的版本
-		if core.NotSupportedVersion(majorVersion) {
+		lang := v.Lang()
+		if core.NotSupportedVersion(lang) {
 			continue
 		}
-		group[majorVersion] = append(group[majorVersion], version)
+		if _, ok := parsed[lang]; !ok {
+			langs = append(langs, &version.Version{Major: v.Major, Minor: v.Minor, Kind: version.KindRelease})
+		}
+		parsed[lang] = append(parsed[lang], v)
 	}
 
-	// 2) 排序(使用语义化版本)
-	versionsSemantic := make([]*semver.Version, 0)
-	for key := range group {
-		v, err := semver.NewVersion(key)
-		if err != nil {
-			return nil, nil, fmt.Errorf("parse version %s failed: %w", key, err)
+	// 2) 排序(组间及组内均按版本升序)
+	version.Sort(langs)
+	keys := make([]string, 0, len(langs))
+	group := make(map[string][]string, len(langs))
+	for _, lang := range langs {
+		key := lang.Lang()
+		keys = append(keys, key)
+
+		versions := parsed[key]
+		version.Sort(versions)
+		for _, v := range versions {
+			group[key] = append(group[key], v.String())
 		}
-		versionsSemantic = append(versionsSemantic, v)
-	}
-	sort.Sort(semver.Collection(versionsSemantic))
-	keys := make([]string, 0, len(versionsSemantic))
-	for _, v := range versionsSemantic {
-		// 语义化版本会自动增加.0,需要去掉
-		keys = append(keys, strings.TrimSuffix(v.String(), ".0"))
 	}
 
 	return keys, group, nil
@@ -154,3 +158,105 @@ func (r *Runtime) ExistVersion(version string) bool {
 	_, err := os.Stat(versionDir)
 	return err == nil
 }
+
+// LocalVersions 已安装版本(已排序)
+func (r *Runtime) LocalVersions() ([]string, error) {
+	entries, err := os.ReadDir(r.o.versionsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		versions = append(versions, entry.Name())
+	}
+
+	return r.core.SortVersions(versions)
+}
+
+// EffectiveVersion 获取生效版本及其来源(GVM_VERSION环境变量 > 项目版本文件 > 全局版本)
+func (r *Runtime) EffectiveVersion() (string, string, error) {
+	if spec := os.Getenv(EnvVersion); spec != "" {
+		version, err := r.ResolveInstalled(spec)
+		if err != nil {
+			return "", "", fmt.Errorf("%s (set by %s)", err, EnvVersion)
+		}
+		return version, OriginSession, nil
+	}
+
+	version, err := r.ProjectVersion()
+	if err == nil {
+		return version, OriginProject, nil
+	}
+	if !errors.Is(err, project.ErrNotFound) {
+		return "", "", err
+	}
+
+	version = r.CurrentVersion()
+	if version == core.NoneVersion {
+		return "", "", fmt.Errorf("no go version selected, run gvm use first")
+	}
+	return version, OriginGlobal, nil
+}
+
+// ProjectVersion 从当前目录向上查找项目声明的版本,并解析为已安装版本
+func (r *Runtime) ProjectVersion() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := project.Find(cwd)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := r.ResolveInstalled(spec.Version)
+	if err != nil && spec.Minimum {
+		// go指令为最低版本要求,可使用同一版本线中不低于该版本的已安装版本
+		if minimum, minErr := r.ResolveInstalled("~" + spec.Version); minErr == nil {
+			version, err = minimum, nil
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s (declared in %s)", err, spec.File)
+	}
+	r.logger.Info("project version", "version", version, "declared", spec.Version, "file", spec.File)
+	return version, nil
+}
+
+// ResolveInstalled 将版本约束或别名解析为已安装版本(如 1.22 解析为已安装的最新 1.22.x)
+func (r *Runtime) ResolveInstalled(spec string) (string, error) {
+	if r.ExistVersion(spec) {
+		return spec, nil
+	}
+
+	versions, err := r.LocalVersions()
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	candidates := make([]resolver.Candidate, 0, len(versions))
+	for _, version := range versions {
+		if r.ExistVersion(version) {
+			candidates = append(candidates, resolver.Candidate{Version: version, Stable: !core.IsBetaOrRC(version)})
+		}
+	}
+
+	version, err := resolver.Resolve(spec, candidates)
+	if err != nil {
+		return "", fmt.Errorf("version %s is not installed", spec)
+	}
+	r.logResolved(spec, version)
+	return version, nil
+}
+
+// logResolved 显示版本解析结果
+func (r *Runtime) logResolved(spec string, version string) {
+	if spec != version {
+		r.logger.Info("resolved", "spec", spec, "version", version)
+	}
+}
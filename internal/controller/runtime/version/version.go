@@ -0,0 +1,115 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind 发布类型(beta < rc < 正式版本)
+type Kind int
+
+const (
+	KindBeta Kind = iota
+	KindRC
+	KindRelease
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindBeta:
+		return "beta"
+	case KindRC:
+		return "rc"
+	default:
+		return ""
+	}
+}
+
+// Version Go发布版本(如 1.20/1.21.0/1.21rc2/1.22beta1)
+type Version struct {
+	Major int  // 主版本号
+	Minor int  // 次版本号
+	Patch int  // 修订版本号
+	Kind  Kind // 发布类型
+	Pre   int  // beta/rc 序号
+}
+
+var versionRegexp = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:(beta|rc)(\d+))?$`)
+
+// Parse 解析Go发布版本(兼容go前缀)
+func Parse(s string) (*Version, error) {
+	matches := versionRegexp.FindStringSubmatch(strings.TrimPrefix(strings.TrimSpace(s), "go"))
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("invalid go version %q", s)
+	}
+	// 预发布版本不带修订版本号(如 1.21rc2)
+	if matches[3] != "" && matches[4] != "" {
+		return nil, fmt.Errorf("invalid go version %q", s)
+	}
+
+	v := &Version{Kind: KindRelease}
+	v.Major, _ = strconv.Atoi(matches[1])
+	v.Minor, _ = strconv.Atoi(matches[2])
+	v.Patch, _ = strconv.Atoi(matches[3])
+	switch matches[4] {
+	case "beta":
+		v.Kind = KindBeta
+	case "rc":
+		v.Kind = KindRC
+	}
+	v.Pre, _ = strconv.Atoi(matches[5])
+	return v, nil
+}
+
+// String 规范格式(1.21之前的首个正式版本为两段,如 1.20;之后为三段,如 1.21.0)
+func (v *Version) String() string {
+	if v.IsPrerelease() {
+		return fmt.Sprintf("%d.%d%s%d", v.Major, v.Minor, v.Kind, v.Pre)
+	}
+	if v.Patch == 0 && v.Major == 1 && v.Minor < 21 {
+		return v.Lang()
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Lang 语言版本(如 1.21rc2 => 1.21)
+func (v *Version) Lang() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// IsPrerelease 是否为beta/rc版本
+func (v *Version) IsPrerelease() bool {
+	return v.Kind != KindRelease
+}
+
+// Compare 比较版本(v < o 返回负数, v == o 返回0, v > o 返回正数)
+func (v *Version) Compare(o *Version) int {
+	switch {
+	case v.Major != o.Major:
+		return v.Major - o.Major
+	case v.Minor != o.Minor:
+		return v.Minor - o.Minor
+	case v.Patch != o.Patch:
+		return v.Patch - o.Patch
+	case v.Kind != o.Kind:
+		return int(v.Kind) - int(o.Kind)
+	default:
+		return v.Pre - o.Pre
+	}
+}
+
+// Sort 升序排序
+func Sort(versions []*Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) < 0
+	})
+}
+
+// IsPrerelease 是否为beta/rc版本(无法解析时返回false)
+func IsPrerelease(s string) bool {
+	v, err := Parse(s)
+	return err == nil && v.IsPrerelease()
+}
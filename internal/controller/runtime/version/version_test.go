@@ -0,0 +1,156 @@
+package version
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{in: "1.22.4", want: Version{Major: 1, Minor: 22, Patch: 4, Kind: KindRelease}},
+		{in: "go1.22", want: Version{Major: 1, Minor: 22, Kind: KindRelease}},
+		{in: " go1.21.0 ", want: Version{Major: 1, Minor: 21, Kind: KindRelease}},
+		{in: "1.23rc2", want: Version{Major: 1, Minor: 23, Kind: KindRC, Pre: 2}},
+		{in: "go1.22beta1", want: Version{Major: 1, Minor: 22, Kind: KindBeta, Pre: 1}},
+		{in: "", wantErr: true},
+		{in: "go", wantErr: true},
+		{in: "1.22.4rc1", wantErr: true},
+		{in: "1.22rc", wantErr: true},
+		{in: "v1.22.4", wantErr: true},
+		{in: "1.22.4-custom", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if *got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "go1.20", want: "1.20"},
+		{in: "1.20.0", want: "1.20"},
+		{in: "1.21", want: "1.21.0"},
+		{in: "1.22.4", want: "1.22.4"},
+		{in: "1.23rc1", want: "1.23rc1"},
+		{in: "1.23beta2", want: "1.23beta2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			v, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if got := v.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // 仅比较符号
+	}{
+		{a: "1.23beta1", b: "1.23rc1", want: -1},
+		{a: "1.23rc1", b: "1.23.0", want: -1},
+		{a: "1.23beta2", b: "1.23.0", want: -1},
+		{a: "1.23rc1", b: "1.23rc2", want: -1},
+		{a: "1.22.4", b: "1.23beta1", want: -1},
+		{a: "1.21", b: "1.21.0", want: 0},
+		{a: "go1.22.4", b: "1.22.4", want: 0},
+		{a: "1.22.10", b: "1.22.9", want: 1},
+		{a: "1.10", b: "1.9", want: 1},
+		{a: "2.0.0", b: "1.99.0", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			a, err := Parse(tt.a)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.a, err)
+			}
+			b, err := Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.b, err)
+			}
+			if got := sign(a.Compare(b)); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := sign(b.Compare(a)); got != -tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	in := []string{"1.22.0", "1.21rc2", "1.21.10", "1.21beta1", "1.21.2", "1.9", "1.21.0", "1.21rc1"}
+	want := []string{"1.9", "1.21beta1", "1.21rc1", "1.21rc2", "1.21.0", "1.21.2", "1.21.10", "1.22.0"}
+
+	versions := make([]*Version, 0, len(in))
+	for _, s := range in {
+		v, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", s, err)
+		}
+		versions = append(versions, v)
+	}
+	Sort(versions)
+
+	for i, v := range versions {
+		if got := v.String(); got != want[i] {
+			t.Errorf("Sort()[%d] = %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{in: "1.23rc1", want: true},
+		{in: "go1.23beta1", want: true},
+		{in: "1.22.4", want: false},
+		{in: "custom", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := IsPrerelease(tt.in); got != tt.want {
+				t.Errorf("IsPrerelease(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
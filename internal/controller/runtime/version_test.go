@@ -0,0 +1,135 @@
+package runtime
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/justwhenjing/gvm/internal/controller/config"
+	"github.com/justwhenjing/gvm/internal/util/log"
+)
+
+// newTestRuntime 创建使用临时根目录的运行时,并按versions创建已安装版本
+func newTestRuntime(t *testing.T, versions ...string) *Runtime {
+	t.Helper()
+	root := t.TempDir()
+	for _, version := range versions {
+		if err := os.MkdirAll(filepath.Join(root, "versions", version, "go", "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	logger, err := log.NewLogger(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewRuntime(logger, &config.Config{RootDir: root}).(*Runtime)
+}
+
+func TestProjectVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed []string
+		file      string
+		content   string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "go.mod exact",
+			installed: []string{"1.22.4", "1.22.6"},
+			file:      "go.mod",
+			content:   "module example.com/m\n\ngo 1.22.4\n",
+			want:      "1.22.4",
+		},
+		{
+			name:      "go.mod newer patch",
+			installed: []string{"1.22.2", "1.22.6"},
+			file:      "go.mod",
+			content:   "module example.com/m\n\ngo 1.22.4\n",
+			want:      "1.22.6",
+		},
+		{
+			name:      "go.mod below minimum",
+			installed: []string{"1.22.2"},
+			file:      "go.mod",
+			content:   "module example.com/m\n\ngo 1.22.4\n",
+			wantErr:   "version 1.22.4 is not installed",
+		},
+		{
+			name:      "go.mod other line",
+			installed: []string{"1.23.0"},
+			file:      "go.mod",
+			content:   "module example.com/m\n\ngo 1.22.4\n",
+			wantErr:   "version 1.22.4 is not installed",
+		},
+		{
+			name:      "go.mod language version",
+			installed: []string{"1.22.2"},
+			file:      "go.mod",
+			content:   "module example.com/m\n\ngo 1.22\n",
+			want:      "1.22.2",
+		},
+		{
+			name:      "go-version exact",
+			installed: []string{"1.22.6"},
+			file:      ".go-version",
+			content:   "1.22.4\n",
+			wantErr:   "version 1.22.4 is not installed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRuntime(t, tt.installed...)
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			t.Chdir(dir)
+
+			got, err := r.ProjectVersion()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ProjectVersion() = %q, %v, want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ProjectVersion() error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ProjectVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRemoteExact(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{spec: "1.22.4", want: "1.22.4"},
+		{spec: "go1.22.4", want: "1.22.4"},
+		{spec: " go1.21.0 ", want: "1.21.0"},
+		{spec: "go1.23rc1", want: "1.23rc1"},
+		{spec: "1.20.0", want: "1.20"},
+	}
+
+	// 精确版本无需获取远程版本列表
+	r := newTestRuntime(t)
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := r.ResolveRemote(tt.spec)
+			if err != nil {
+				t.Fatalf("ResolveRemote(%q) error: %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveRemote(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package fileop
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// errLocked 锁已被其他进程持有
+var errLocked = errors.New("locked by another process")
+
+// lockPollInterval 等待锁的轮询间隔
+const lockPollInterval = 100 * time.Millisecond
+
+// Lock 文件锁(进程间建议锁,锁文件内容为持有者PID)
+type Lock struct {
+	path string   // 锁文件路径
+	fObj *os.File // 锁文件
+}
+
+// NewLock 获取文件锁,超时前每次等待都会调用onWait(参数为持有者PID)
+func NewLock(path string, timeout time.Duration, onWait func(holder string)) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	// #nosec G304
+	fObj, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	waited := false
+	for {
+		err := tryLock(fObj)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errLocked) {
+			_ = fObj.Close()
+			return nil, fmt.Errorf("lock %s failed: %w", path, err)
+		}
+
+		holder := lockHolder(path)
+		if time.Now().After(deadline) {
+			_ = fObj.Close()
+			return nil, fmt.Errorf("%s is locked by pid %s, gave up after %s", path, holder, timeout)
+		}
+		if !waited && onWait != nil {
+			onWait(holder)
+		}
+		waited = true
+		time.Sleep(lockPollInterval)
+	}
+
+	// 记录持有者PID
+	_ = fObj.Truncate(0)
+	_, _ = fObj.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	return &Lock{path: path, fObj: fObj}, nil
+}
+
+// Unlock 释放文件锁
+func (l *Lock) Unlock() error {
+	_ = l.fObj.Truncate(0)
+	err := unlock(l.fObj)
+	if e := l.fObj.Close(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// lockHolder 读取锁持有者PID
+func lockHolder(path string) string {
+	// #nosec G304
+	content, err := os.ReadFile(path)
+	if err != nil || len(strings.TrimSpace(string(content))) == 0 {
+		return "unknown"
+	}
+	return strings.TrimSpace(string(content))
+}
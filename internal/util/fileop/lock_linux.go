@@ -0,0 +1,23 @@
+//go:build linux
+// +build linux
+
+package fileop
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func tryLock(fObj *os.File) error {
+	err := unix.Flock(int(fObj.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	if errors.Is(err, unix.EWOULDBLOCK) {
+		return errLocked
+	}
+	return err
+}
+
+func unlock(fObj *os.File) error {
+	return unix.Flock(int(fObj.Fd()), unix.LOCK_UN)
+}
@@ -7,6 +7,7 @@ type IHttp interface {
 
 	Get(url string, query map[string]string) (*resty.Response, error)
 	GetWithOutput(url string, output string) (*resty.Response, error)
+	GetStream(url string, header map[string]string) (*resty.Response, error)
 
 	Post(url string, body interface{}) (*resty.Response, error)
 	Patch(url string, body interface{}) (*resty.Response, error)
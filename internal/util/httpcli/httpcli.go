@@ -26,6 +26,11 @@ func (c *Client) GetWithOutput(url string, output string) (*resty.Response, erro
 	return c.o.client.R().SetOutput(output).Get(url)
 }
 
+// GetStream 获取响应流(调用方负责关闭 RawBody)
+func (c *Client) GetStream(url string, header map[string]string) (*resty.Response, error) {
+	return c.o.client.R().SetHeaders(header).SetDoNotParseResponse(true).Get(url)
+}
+
 func (c *Client) Post(url string, body interface{}) (*resty.Response, error) {
 	return c.o.client.R().SetBody(body).Post(url)
 }